@@ -3,17 +3,26 @@ package goiterators
 import "iter"
 
 // Map transforms each item using the provided function
-func Map[T any, U any](iterator Iterator[T], fn func(T) U) Iterator[U] {
+func Map[T any, U any](iterator Iterator[T], fn func(T) U, opts ...Option) Iterator[U] {
 	return IMap(iterator, func(_ int, item T) U {
 		return fn(item)
-	})
+	}, opts...)
 }
 
 // IMap transforms each item using the provided function
-func IMap[T, U any](iter Iterator[T], fn func(int, T) U) Iterator[U] {
-	return newIterator(func(self *iterator[U], yield func(int, U) bool) {
+func IMap[T, U any](iter Iterator[T], fn func(int, T) U, opts ...Option) Iterator[U] {
+	cfg := newConfig(opts)
+	return withSize(withStage(newIterator(func(self *iterator[U], yield func(int, U) bool) {
 		for idx, item := range iter.INext {
-			if !yield(idx, fn(idx, item)) {
+			result, err := callSync(cfg, idx, func() (U, error) {
+				return fn(idx, item), nil
+			})
+			if err != nil {
+				self.err = err
+				return
+			}
+
+			if !yield(idx, result) {
 				return
 			}
 		}
@@ -21,21 +30,30 @@ func IMap[T, U any](iter Iterator[T], fn func(int, T) U) Iterator[U] {
 		if iter.Err() != nil {
 			self.err = iter.Err()
 		}
-	})
+	}), "Map", cfg, iter), SizeHintOf(iter))
 }
 
 // Filter returns only items that satisfy the predicate function
-func Filter[T any](iterator Iterator[T], fn func(T) bool) Iterator[T] {
+func Filter[T any](iterator Iterator[T], fn func(T) bool, opts ...Option) Iterator[T] {
 	return IFilter(iterator, func(_ int, item T) bool {
 		return fn(item)
-	})
+	}, opts...)
 }
 
 // IFilter returns only items that satisfy the predicate function with index
-func IFilter[T any](iter Iterator[T], fn func(int, T) bool) Iterator[T] {
-	return newIterator(func(self *iterator[T], yield func(int, T) bool) {
+func IFilter[T any](iter Iterator[T], fn func(int, T) bool, opts ...Option) Iterator[T] {
+	cfg := newConfig(opts)
+	return withSize(withStage(newIterator(func(self *iterator[T], yield func(int, T) bool) {
 		for idx, item := range iter.INext {
-			if fn(idx, item) {
+			match, err := callSync(cfg, idx, func() (bool, error) {
+				return fn(idx, item), nil
+			})
+			if err != nil {
+				self.err = err
+				return
+			}
+
+			if match {
 				if !yield(idx, item) {
 					return
 				}
@@ -44,22 +62,28 @@ func IFilter[T any](iter Iterator[T], fn func(int, T) bool) Iterator[T] {
 		if iter.Err() != nil {
 			self.err = iter.Err()
 		}
-	})
+	}), "Filter", cfg, iter), SizeHintOf(iter).upTo())
 }
 
 // Take returns at most n items from the iterator
 func Take[T any](iter Iterator[T], n int) Iterator[T] {
-	return newIterator(func(self *iterator[T], yield func(int, T) bool) {
+	if source, ok := sliceOf(iter); ok {
+		return withStage(newSliceIterator(source.sub(0, min(max(n, 0), source.len()))), "Take", nil, iter)
+	}
+
+	return withSize(withStage(newIterator(func(self *iterator[T], yield func(int, T) bool) {
 		if n <= 0 {
 			return
 		}
 
+		taken := 0
 		for idx, item := range iter.INext {
 			if !yield(idx, item) {
 				return
 			}
 
-			if idx >= (n - 1) {
+			taken++
+			if taken >= n {
 				return
 			}
 		}
@@ -67,22 +91,31 @@ func Take[T any](iter Iterator[T], n int) Iterator[T] {
 		if iter.Err() != nil {
 			self.err = iter.Err()
 		}
-	})
+	}), "Take", nil, iter), SizeHintOf(iter).limit(n))
 }
 
 // FlatMap transforms each item into multiple results using iter.Seq
-func FlatMap[T, U any](iterator Iterator[T], fn func(T) iter.Seq[U]) Iterator[U] {
+func FlatMap[T, U any](iterator Iterator[T], fn func(T) iter.Seq[U], opts ...Option) Iterator[U] {
 	return IFlatMap(iterator, func(_ int, t T) iter.Seq[U] {
 		return fn(t)
-	})
+	}, opts...)
 }
 
 // IFlatMap transforms each item into multiple results using iter.Seq with index
-func IFlatMap[T, U any](iter Iterator[T], fn func(int, T) iter.Seq[U]) Iterator[U] {
-	return newIterator(func(self *iterator[U], yield func(int, U) bool) {
+func IFlatMap[T, U any](it Iterator[T], fn func(int, T) iter.Seq[U], opts ...Option) Iterator[U] {
+	cfg := newConfig(opts)
+	return withStage(newIterator(func(self *iterator[U], yield func(int, U) bool) {
 		outputIdx := 0
-		for idx, item := range iter.INext {
-			for result := range fn(idx, item) {
+		for idx, item := range it.INext {
+			results, err := callSync(cfg, idx, func() (iter.Seq[U], error) {
+				return fn(idx, item), nil
+			})
+			if err != nil {
+				self.err = err
+				return
+			}
+
+			for result := range results {
 				if !yield(outputIdx, result) {
 					return
 				}
@@ -90,23 +123,27 @@ func IFlatMap[T, U any](iter Iterator[T], fn func(int, T) iter.Seq[U]) Iterator[
 			}
 		}
 
-		if iter.Err() != nil {
-			self.err = iter.Err()
+		if it.Err() != nil {
+			self.err = it.Err()
 		}
-	})
+	}), "FlatMap", cfg, it)
 }
 
 // ForEach applies the provided function to each item in the iterator
-func ForEach[T any](iter Iterator[T], fn func(T) error) error {
+func ForEach[T any](iter Iterator[T], fn func(T) error, opts ...Option) error {
 	return IForEach(iter, func(_ int, item T) error {
 		return fn(item)
-	})
+	}, opts...)
 }
 
 // IForEach applies the provided function to each item in the iterator with index
-func IForEach[T any](iter Iterator[T], fn func(int, T) error) error {
+func IForEach[T any](iter Iterator[T], fn func(int, T) error, opts ...Option) error {
+	cfg := newConfig(opts)
 	for idx, item := range iter.INext {
-		if err := fn(idx, item); err != nil {
+		_, err := callSync(cfg, idx, func() (struct{}, error) {
+			return struct{}{}, fn(idx, item)
+		})
+		if err != nil {
 			return err
 		}
 	}
@@ -161,7 +161,7 @@ func TestChainedOperations(t *testing.T) {
 
 	result := slices.Collect(taken.Next)
 
-	expected := []int{4}
+	expected := []int{4, 8}
 	assert.Equal(t, expected, result)
 }
 
@@ -3,24 +3,64 @@ package goiterators
 import (
 	"context"
 	"iter"
+	"runtime/pprof"
 	"slices"
 	"sync"
 )
 
 // processAsync provides async processing with context cancellation support
 // The worker function is called for each item with its index and can send zero or more results to the channel
-func processAsync[T, U any](ctx context.Context, iter Iterator[T], worker func(context.Context, int, T, chan<- Result[U])) Iterator[U] {
-	channel := make(chan Result[U])
+// Every goroutine of the stage carries the pprof labels of algorithm, which workers can read from their context
+func processAsync[T, U any](ctx context.Context, algorithm string, iter Iterator[T], cfg *config, worker func(context.Context, int, T, chan<- Result[U])) Iterator[U] {
+	ctx = cfg.labelled(ctx, algorithm)
+	size := SizeHintOf(iter)
+	output := newAsyncOutput[U](ctx, cfg, size)
+
+	// Slice sources are split among a fixed pool of workers only when WithConcurrency sets its size
+	// Without it every item gets its own goroutine, which I/O bound functions rely on to all wait in parallel,
+	// and a pool sized from the CPU count would serialize them
+	if source, ok := sliceOf(iter); ok && cfg.concurrency > 0 {
+		processChunked(ctx, source, cfg, output, worker)
+		return &asyncIterator[U]{
+			dataIn:  output.channel,
+			repanic: cfg.repanic,
+		}
+	}
 
 	go func() {
-		defer close(channel)
+		pprof.SetGoroutineLabels(ctx)
+		defer output.close()
 		wg := sync.WaitGroup{}
 
+		items := 0
+		finish := cfg.logStageStart()
+		defer func() { finish(items) }()
+
+		// Recover from panics in the underlying iterator
+		defer func() {
+			if r := recover(); r != nil {
+				err := newPanicError(r)
+				cfg.logStageError(err)
+				output.send(Result[U]{Value: *new(U), Err: err})
+				wg.Wait()
+			}
+		}()
+
+		// Bound the number of workers running at once, whatever the size hint says as a wrong hint must not lift the limit
+		var slots chan struct{}
+		if cfg.concurrency > 0 {
+			slots = make(chan struct{}, cfg.concurrency)
+		}
+
 		for idx, item := range iter.INext {
+			cfg.itemIn(idx)
+			items++
+
 			// Check for context cancellation
 			select {
 			case <-ctx.Done():
-				channel <- Result[U]{Value: *new(U), Err: ctx.Err()}
+				cfg.logStageError(ctx.Err())
+				output.send(Result[U]{Value: *new(U), Err: ctx.Err()})
 				wg.Wait() // Wait for any pending goroutines
 				return
 			default:
@@ -28,14 +68,50 @@ func processAsync[T, U any](ctx context.Context, iter Iterator[T], worker func(c
 
 			// Check for error from underlying iterator
 			if iter.Err() != nil {
-				channel <- Result[U]{Value: *new(U), Err: iter.Err()}
+				cfg.logStageError(iter.Err())
+				output.send(Result[U]{Value: *new(U), Err: iter.Err()})
 				wg.Wait() // Wait for any pending goroutines
 				return
 			}
 
+			// Wait for a free worker slot before spawning the worker
+			if slots != nil {
+				select {
+				case <-ctx.Done():
+					cfg.logStageError(ctx.Err())
+					output.send(Result[U]{Value: *new(U), Err: ctx.Err()})
+					wg.Wait() // Wait for any pending goroutines
+					return
+				case slots <- struct{}{}:
+				}
+			}
+
+			// Wait for the rate limiter before spawning the worker
+			if cfg.limiter != nil {
+				if err := cfg.limiter.Wait(ctx); err != nil {
+					cfg.logStageError(err)
+					output.send(Result[U]{Value: *new(U), Err: err})
+					wg.Wait() // Wait for any pending goroutines
+					return
+				}
+			}
+
+			channel, done := output.slot()
 			wg.Add(1)
 			go func(idx int, item T) {
 				defer wg.Done()
+				defer done()
+				defer cfg.workerStart(idx)()
+				if slots != nil {
+					defer func() { <-slots }()
+				}
+				defer func() {
+					if r := recover(); r != nil {
+						err := newPanicError(r)
+						cfg.logItemError(ctx, idx, err)
+						channel <- Result[U]{Value: *new(U), Err: err}
+					}
+				}()
 				select {
 				case <-ctx.Done():
 					channel <- Result[U]{Value: *new(U), Err: ctx.Err()}
@@ -58,86 +134,100 @@ func processAsync[T, U any](ctx context.Context, iter Iterator[T], worker func(c
 			// All work completed normally
 		case <-ctx.Done():
 			// Context cancelled while waiting
-			channel <- Result[U]{Value: *new(U), Err: ctx.Err()}
+			cfg.logStageError(ctx.Err())
+			output.send(Result[U]{Value: *new(U), Err: ctx.Err()})
 			wg.Wait() // Still wait for goroutines to finish
 			return
 		}
 
 		// Final check for errors after processing all items
 		if iter.Err() != nil {
-			channel <- Result[U]{Value: *new(U), Err: iter.Err()}
+			cfg.logStageError(iter.Err())
+			output.send(Result[U]{Value: *new(U), Err: iter.Err()})
 		}
 	}()
 
-	return NewAsyncIteratorErr(channel)
+	return &asyncIterator[U]{
+		dataIn:  output.channel,
+		repanic: cfg.repanic,
+	}
 }
 
 // IMapAsyncCtx transforms each item using the provided function with index in parallel with context cancellation
-func IMapAsyncCtx[T any, U any](ctx context.Context, iter Iterator[T], fn func(context.Context, int, T) (U, error)) Iterator[U] {
-	return processAsync(ctx, iter, func(ctx context.Context, idx int, item T, ch chan<- Result[U]) {
-		result, err := fn(ctx, idx, item)
+func IMapAsyncCtx[T any, U any](ctx context.Context, iter Iterator[T], fn func(context.Context, int, T) (U, error), opts ...Option) Iterator[U] {
+	cfg := newConfig(opts)
+	return withSize(withAsyncStage(processAsync(ctx, "MapAsync", iter, cfg, func(ctx context.Context, idx int, item T, ch chan<- Result[U]) {
+		result, err := invoke(ctx, cfg, idx, func(ctx context.Context) (U, error) {
+			return fn(ctx, idx, item)
+		})
 		ch <- Result[U]{Value: result, Err: err}
-	})
+	}), "MapAsync", cfg, iter), SizeHintOf(iter))
 }
 
 // MapAsync transforms each item using the provided function in parallel
-func MapAsync[T any, U any](iter Iterator[T], fn func(T) U) Iterator[U] {
+func MapAsync[T any, U any](iter Iterator[T], fn func(T) U, opts ...Option) Iterator[U] {
 	return IMapAsync(iter, func(_ int, item T) U {
 		return fn(item)
-	})
+	}, opts...)
 }
 
 // IMapAsync transforms each item using the provided function with index in parallel
-func IMapAsync[T any, U any](iter Iterator[T], fn func(int, T) U) Iterator[U] {
+func IMapAsync[T any, U any](iter Iterator[T], fn func(int, T) U, opts ...Option) Iterator[U] {
 	return IMapAsyncCtx(context.Background(), iter, func(ctx context.Context, i int, t T) (U, error) {
 		return fn(i, t), nil
-	})
+	}, opts...)
 }
 
 // MapAsyncCtx transforms each item using the provided function in parallel with context cancellation
-func MapAsyncCtx[T any, U any](ctx context.Context, iter Iterator[T], fn func(context.Context, T) (U, error)) Iterator[U] {
+func MapAsyncCtx[T any, U any](ctx context.Context, iter Iterator[T], fn func(context.Context, T) (U, error), opts ...Option) Iterator[U] {
 	return IMapAsyncCtx(ctx, iter, func(ctx context.Context, _ int, item T) (U, error) {
 		return fn(ctx, item)
-	})
+	}, opts...)
 }
 
 // IFilterAsyncCtx returns only items that satisfy the predicate function with index in parallel with context cancellation
-func IFilterAsyncCtx[T any](ctx context.Context, iter Iterator[T], fn func(context.Context, int, T) (bool, error)) Iterator[T] {
-	return processAsync(ctx, iter, func(ctx context.Context, idx int, item T, ch chan<- Result[T]) {
-		match, err := fn(ctx, idx, item)
+func IFilterAsyncCtx[T any](ctx context.Context, iter Iterator[T], fn func(context.Context, int, T) (bool, error), opts ...Option) Iterator[T] {
+	cfg := newConfig(opts)
+	return withSize(withAsyncStage(processAsync(ctx, "FilterAsync", iter, cfg, func(ctx context.Context, idx int, item T, ch chan<- Result[T]) {
+		match, err := invoke(ctx, cfg, idx, func(ctx context.Context) (bool, error) {
+			return fn(ctx, idx, item)
+		})
 		if err != nil {
 			ch <- Result[T]{Value: *new(T), Err: err}
 		} else if match {
 			ch <- Result[T]{Value: item, Err: nil}
 		}
-	})
+	}), "FilterAsync", cfg, iter), SizeHintOf(iter).upTo())
 }
 
 // FilterAsyncCtx returns only items that satisfy the predicate function in parallel with context cancellation
-func FilterAsyncCtx[T any](ctx context.Context, iter Iterator[T], fn func(context.Context, T) (bool, error)) Iterator[T] {
+func FilterAsyncCtx[T any](ctx context.Context, iter Iterator[T], fn func(context.Context, T) (bool, error), opts ...Option) Iterator[T] {
 	return IFilterAsyncCtx(ctx, iter, func(ctx context.Context, i int, t T) (bool, error) {
 		return fn(ctx, t)
-	})
+	}, opts...)
 }
 
 // FilterAsync returns only items that satisfy the predicate function in parallel
-func FilterAsync[T any](iter Iterator[T], fn func(T) bool) Iterator[T] {
+func FilterAsync[T any](iter Iterator[T], fn func(T) bool, opts ...Option) Iterator[T] {
 	return IFilterAsync(iter, func(i int, t T) bool {
 		return fn(t)
-	})
+	}, opts...)
 }
 
 // IFilterAsync returns only items that satisfy the predicate function with index in parallel
-func IFilterAsync[T any](iter Iterator[T], fn func(int, T) bool) Iterator[T] {
+func IFilterAsync[T any](iter Iterator[T], fn func(int, T) bool, opts ...Option) Iterator[T] {
 	return IFilterAsyncCtx(context.Background(), iter, func(ctx context.Context, i int, t T) (bool, error) {
 		return fn(i, t), nil
-	})
+	}, opts...)
 }
 
 // IFlatMapAsyncCtx transforms each item into multiple results with index in parallel with context cancellation
-func IFlatMapAsyncCtx[T, U any](ctx context.Context, iter Iterator[T], fn func(context.Context, int, T) (iter.Seq[U], error)) Iterator[U] {
-	return processAsync(ctx, iter, func(ctx context.Context, idx int, item T, ch chan<- Result[U]) {
-		results, err := fn(ctx, idx, item)
+func IFlatMapAsyncCtx[T, U any](ctx context.Context, iterator Iterator[T], fn func(context.Context, int, T) (iter.Seq[U], error), opts ...Option) Iterator[U] {
+	cfg := newConfig(opts)
+	return withAsyncStage(processAsync(ctx, "FlatMapAsync", iterator, cfg, func(ctx context.Context, idx int, item T, ch chan<- Result[U]) {
+		results, err := invoke(ctx, cfg, idx, func(ctx context.Context) (iter.Seq[U], error) {
+			return fn(ctx, idx, item)
+		})
 		if err != nil {
 			ch <- Result[U]{Value: *new(U), Err: err}
 		} else {
@@ -150,34 +240,38 @@ func IFlatMapAsyncCtx[T, U any](ctx context.Context, iter Iterator[T], fn func(c
 				}
 			}
 		}
-	})
+	}), "FlatMapAsync", cfg, iterator)
 }
 
 // FlatMapAsync transforms each item into multiple results in parallel
-func FlatMapAsync[T, U any](iterator Iterator[T], fn func(T) iter.Seq[U]) Iterator[U] {
+func FlatMapAsync[T, U any](iterator Iterator[T], fn func(T) iter.Seq[U], opts ...Option) Iterator[U] {
 	return IFlatMapAsync(iterator, func(_ int, item T) iter.Seq[U] {
 		return fn(item)
-	})
+	}, opts...)
 }
 
 // IFlatMapAsync transforms each item into multiple results with index in parallel
-func IFlatMapAsync[T, U any](iterator Iterator[T], fn func(int, T) iter.Seq[U]) Iterator[U] {
+func IFlatMapAsync[T, U any](iterator Iterator[T], fn func(int, T) iter.Seq[U], opts ...Option) Iterator[U] {
 	return IFlatMapAsyncCtx(context.Background(), iterator, func(ctx context.Context, i int, t T) (iter.Seq[U], error) {
 		return fn(i, t), nil
-	})
+	}, opts...)
 }
 
 // FlatMapAsyncCtx transforms each item into multiple results in parallel with context cancellation
-func FlatMapAsyncCtx[T, U any](ctx context.Context, iterator Iterator[T], fn func(context.Context, T) (iter.Seq[U], error)) Iterator[U] {
+func FlatMapAsyncCtx[T, U any](ctx context.Context, iterator Iterator[T], fn func(context.Context, T) (iter.Seq[U], error), opts ...Option) Iterator[U] {
 	return IFlatMapAsyncCtx(ctx, iterator, func(ctx context.Context, i int, t T) (iter.Seq[U], error) {
 		return fn(ctx, t)
-	})
+	}, opts...)
 }
 
 // IForEachAsyncCtx applies the function to each item with index in parallel with context cancellation
-func IForEachAsyncCtx[T any](ctx context.Context, iter Iterator[T], fn func(context.Context, int, T) error) error {
-	processIterator := processAsync(ctx, iter, func(ctx context.Context, i int, t T, c chan<- Result[struct{}]) {
-		c <- Result[struct{}]{Value: struct{}{}, Err: fn(ctx, i, t)}
+func IForEachAsyncCtx[T any](ctx context.Context, iter Iterator[T], fn func(context.Context, int, T) error, opts ...Option) error {
+	cfg := newConfig(opts)
+	processIterator := processAsync(ctx, "ForEachAsync", iter, cfg, func(ctx context.Context, i int, t T, c chan<- Result[struct{}]) {
+		err := cfg.call(ctx, i, func(ctx context.Context) error {
+			return fn(ctx, i, t)
+		})
+		c <- Result[struct{}]{Value: struct{}{}, Err: err}
 	})
 
 	_ = slices.Collect(processIterator.Next)
@@ -186,22 +280,22 @@ func IForEachAsyncCtx[T any](ctx context.Context, iter Iterator[T], fn func(cont
 }
 
 // IForEachAsync applies the function to each item with index in parallel
-func IForEachAsync[T any](iter Iterator[T], fn func(int, T) error) error {
+func IForEachAsync[T any](iter Iterator[T], fn func(int, T) error, opts ...Option) error {
 	return IForEachAsyncCtx(context.Background(), iter, func(_ context.Context, i int, t T) error {
 		return fn(i, t)
-	})
+	}, opts...)
 }
 
 // ForEachAsyncCtx applies the function to each item with index in parallel with context cancellation
-func ForEachAsyncCtx[T any](ctx context.Context, iter Iterator[T], fn func(context.Context, T) error) error {
+func ForEachAsyncCtx[T any](ctx context.Context, iter Iterator[T], fn func(context.Context, T) error, opts ...Option) error {
 	return IForEachAsyncCtx(ctx, iter, func(ctx context.Context, i int, t T) error {
 		return fn(ctx, t)
-	})
+	}, opts...)
 }
 
 // ForEachAsync applies the function to each item with index in parallel
-func ForEachAsync[T any](iter Iterator[T], fn func(T) error) error {
+func ForEachAsync[T any](iter Iterator[T], fn func(T) error, opts ...Option) error {
 	return ForEachAsyncCtx(context.Background(), iter, func(_ context.Context, t T) error {
 		return fn(t)
-	})
+	}, opts...)
 }
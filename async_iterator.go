@@ -1,5 +1,11 @@
 package goiterators
 
+import (
+	"context"
+	"errors"
+	"runtime/pprof"
+)
+
 // Result wraps a value with an optional error for async operations
 type Result[T any] struct {
 	Value T
@@ -7,14 +13,18 @@ type Result[T any] struct {
 }
 
 type asyncIterator[T any] struct {
-	dataIn <-chan Result[T]
-	err    error
+	dataIn  <-chan Result[T]
+	err     error
+	repanic bool
+	info    *stageInfo
+	size    SizeHint
 }
 
 // NewAsyncIterator creates an async iterator from a channel of values
 func NewAsyncIterator[T any](dataIn <-chan T) Iterator[T] {
 	channel := make(chan Result[T])
 	go func() {
+		pprof.SetGoroutineLabels(pprof.WithLabels(context.Background(), pprof.Labels(LabelAlgorithm, "NewAsyncIterator")))
 		defer close(channel)
 		i := 0
 		for item := range dataIn {
@@ -33,13 +43,14 @@ func NewAsyncIterator[T any](dataIn <-chan T) Iterator[T] {
 func NewAsyncIteratorErr[T any](dataIn <-chan Result[T]) Iterator[T] {
 	return &asyncIterator[T]{
 		dataIn: dataIn,
+		info:   &stageInfo{kind: "Channel"},
 	}
 }
 
 func (it *asyncIterator[T]) Next(yield func(T) bool) {
 	for item := range it.dataIn {
 		if item.Err != nil {
-			it.err = item.Err
+			it.setErr(item.Err)
 			return
 		}
 
@@ -53,7 +64,7 @@ func (it *asyncIterator[T]) INext(yield func(int, T) bool) {
 	i := 0
 	for item := range it.dataIn {
 		if item.Err != nil {
-			it.err = item.Err
+			it.setErr(item.Err)
 			return
 		}
 
@@ -65,6 +76,16 @@ func (it *asyncIterator[T]) INext(yield func(int, T) bool) {
 	}
 }
 
+// setErr records err, re-panicking in the consumer goroutine when err is or wraps a PanicError and the iterator asks to
+func (it *asyncIterator[T]) setErr(err error) {
+	it.err = err
+
+	var panicErr *PanicError
+	if it.repanic && errors.As(err, &panicErr) {
+		panic(panicErr)
+	}
+}
+
 func (it *asyncIterator[T]) Err() error {
 	return it.err
 }
@@ -0,0 +1,80 @@
+package goiterators
+
+import (
+	"context"
+	"runtime/pprof"
+)
+
+// orderedLookahead is the minimum number of items dispatched ahead of the oldest pending item in ordered mode
+const orderedLookahead = 1024
+
+// asyncOutput routes the results of async workers to a single channel, preserving input order when requested
+type asyncOutput[U any] struct {
+	channel chan Result[U]
+	queue   chan chan Result[U]
+}
+
+// newAsyncOutput creates an output for the items bounded by size, starting the goroutine restoring input order when cfg asks for ordered results
+// The goroutine carries the pprof labels of ctx
+func newAsyncOutput[U any](ctx context.Context, cfg *config, size SizeHint) *asyncOutput[U] {
+	output := &asyncOutput[U]{
+		channel: make(chan Result[U]),
+	}
+
+	if cfg.ordered {
+		lookahead := max(cfg.concurrency, orderedLookahead)
+		if size.Bounded {
+			// One slot per item and one for a final error
+			lookahead = min(lookahead, size.Max+1)
+		}
+
+		output.queue = make(chan chan Result[U], lookahead)
+		go func() {
+			pprof.SetGoroutineLabels(ctx)
+			defer close(output.channel)
+			for slot := range output.queue {
+				for result := range slot {
+					output.channel <- result
+				}
+			}
+		}()
+	}
+
+	return output
+}
+
+// slot reserves the position of the next item, returning the channel its results must be sent to
+// and a function to call once every result was sent
+func (o *asyncOutput[U]) slot() (chan<- Result[U], func()) {
+	return o.reserve(0)
+}
+
+// reserve reserves the next position like slot, buffering up to capacity results so the sender can run ahead of the consumer
+func (o *asyncOutput[U]) reserve(capacity int) (chan<- Result[U], func()) {
+	if o.queue == nil {
+		return o.channel, func() {}
+	}
+
+	slot := make(chan Result[U], capacity)
+	o.queue <- slot
+	return slot, func() {
+		close(slot)
+	}
+}
+
+// send emits a single result after every previously reserved slot
+func (o *asyncOutput[U]) send(result Result[U]) {
+	slot, done := o.slot()
+	slot <- result
+	done()
+}
+
+// close signals that no more results will be sent
+func (o *asyncOutput[U]) close() {
+	if o.queue == nil {
+		close(o.channel)
+		return
+	}
+
+	close(o.queue)
+}
@@ -0,0 +1,277 @@
+package goiterators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/pprof"
+	"time"
+)
+
+// BatchError reports a partial failure of a batch function, Errs holds one error per item of the batch and nil for successful items
+type BatchError struct {
+	Errs []error
+}
+
+func (e *BatchError) Error() string {
+	failed := 0
+	for _, err := range e.Errs {
+		if err != nil {
+			failed++
+		}
+	}
+
+	return fmt.Sprintf("%d of %d batch items failed", failed, len(e.Errs))
+}
+
+// ItemError attributes an error to the item at Index of the input iterator
+type ItemError struct {
+	Index int
+	Err   error
+}
+
+func (e *ItemError) Error() string {
+	return fmt.Sprintf("item %d: %v", e.Index, e.Err)
+}
+
+func (e *ItemError) Unwrap() error {
+	return e.Err
+}
+
+type batch[T any] struct {
+	indices []int
+	items   []T
+}
+
+type batchResult[U any] struct {
+	values []U
+	err    error
+	// itemErrs holds the error of every item of the batch when the failure is attributed to items, nil for successful items
+	itemErrs []error
+}
+
+type indexedItem[T any] struct {
+	idx  int
+	item T
+}
+
+// batchItems groups items into batches of at most size items, flushing a partial batch once maxWait elapsed since its first item
+// Every item is reported to the observers of cfg as it is received, its goroutines carry the pprof labels of ctx
+func batchItems[T any](ctx context.Context, iter Iterator[T], cfg *config, size int, maxWait time.Duration) Iterator[batch[T]] {
+	source := make(chan Result[indexedItem[T]])
+	go func() {
+		pprof.SetGoroutineLabels(ctx)
+		defer close(source)
+
+		send := func(result Result[indexedItem[T]]) bool {
+			select {
+			case <-ctx.Done():
+				return false
+			case source <- result:
+				return true
+			}
+		}
+
+		// Recover from panics in the underlying iterator
+		defer func() {
+			if r := recover(); r != nil {
+				send(Result[indexedItem[T]]{Err: newPanicError(r)})
+			}
+		}()
+
+		for idx, item := range iter.INext {
+			cfg.itemIn(idx)
+			if !send(Result[indexedItem[T]]{Value: indexedItem[T]{idx: idx, item: item}}) {
+				return
+			}
+		}
+
+		if iter.Err() != nil {
+			send(Result[indexedItem[T]]{Err: iter.Err()})
+		}
+	}()
+
+	channel := make(chan Result[batch[T]])
+	go func() {
+		pprof.SetGoroutineLabels(ctx)
+		defer close(channel)
+
+		var current batch[T]
+		var timer *time.Timer
+		var timeout <-chan time.Time
+
+		send := func(result Result[batch[T]]) bool {
+			select {
+			case <-ctx.Done():
+				return false
+			case channel <- result:
+				return true
+			}
+		}
+
+		flush := func() bool {
+			if timer != nil {
+				timer.Stop()
+				timer, timeout = nil, nil
+			}
+			if len(current.items) == 0 {
+				return true
+			}
+
+			full := current
+			current = batch[T]{}
+			return send(Result[batch[T]]{Value: full})
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timeout:
+				if !flush() {
+					return
+				}
+			case result, ok := <-source:
+				if !ok {
+					flush()
+					return
+				}
+
+				if result.Err != nil {
+					if flush() {
+						send(Result[batch[T]]{Err: result.Err})
+					}
+					return
+				}
+
+				current.indices = append(current.indices, result.Value.idx)
+				current.items = append(current.items, result.Value.item)
+
+				if len(current.items) == 1 && maxWait > 0 {
+					timer = time.NewTimer(maxWait)
+					timeout = timer.C
+				}
+
+				if len(current.items) >= size && !flush() {
+					return
+				}
+			}
+		}
+	}()
+
+	return NewAsyncIteratorErr(channel)
+}
+
+// attribute splits the outcome of a batch function into the values of successful items and the error of the first failed item
+func attribute[T, U any](b batch[T], values []U, err error) batchResult[U] {
+	if err == nil {
+		if len(values) != len(b.items) {
+			return batchResult[U]{err: fmt.Errorf("batch function returned %d results for %d items", len(values), len(b.items))}
+		}
+
+		return batchResult[U]{values: values}
+	}
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Errs) != len(b.items) || len(values) != len(b.items) {
+		return batchResult[U]{err: err}
+	}
+
+	result := batchResult[U]{itemErrs: make([]error, len(b.items))}
+	for i, itemErr := range batchErr.Errs {
+		if itemErr == nil {
+			result.values = append(result.values, values[i])
+			continue
+		}
+
+		result.itemErrs[i] = &ItemError{Index: b.indices[i], Err: itemErr}
+		if result.err == nil {
+			result.err = result.itemErrs[i]
+		}
+	}
+
+	return result
+}
+
+// reportBatch reports the outcome of a batch to the observers of cfg once per item, with the time the batch function took
+// Failed items are logged with their input index, a failure of the whole batch is logged once with the indices of its items
+func reportBatch[U any](ctx context.Context, cfg *config, indices []int, result batchResult[U], elapsed time.Duration) {
+	if result.itemErrs == nil && result.err != nil {
+		cfg.logBatchError(ctx, indices, result.err)
+	}
+
+	for i, idx := range indices {
+		switch {
+		case result.itemErrs != nil && result.itemErrs[i] != nil:
+			cfg.itemError(idx, result.itemErrs[i])
+			cfg.logItemError(ctx, idx, result.itemErrs[i])
+		case result.itemErrs == nil && result.err != nil:
+			cfg.itemError(idx, result.err)
+		default:
+			cfg.itemOut(idx, elapsed)
+		}
+	}
+}
+
+// MapBatchAsyncCtx transforms items in batches of at most size items in parallel with context cancellation
+// A partial batch is processed once maxWait elapsed since its first item, a non-positive maxWait waits for a full batch
+// fn must return one result per item, or a BatchError alongside the results to fail only some items with an ItemError
+// Results are yielded individually, use WithOrdered to keep the input order and WithConcurrency to bound parallel batches
+// Observers receive the events of every item with its input index, the items of a batch share its worker and its latency
+// Failed items are logged with their input index as well
+func MapBatchAsyncCtx[T, U any](ctx context.Context, iter Iterator[T], size int, maxWait time.Duration, fn func(context.Context, []T) ([]U, error), opts ...Option) Iterator[U] {
+	cfg := newConfig(opts)
+	batches := batchItems(cfg.labelled(ctx, "MapBatchAsync"), iter, cfg, max(size, 1), maxWait)
+
+	// The async stage only sees batches, their events and failures are reported per item below
+	stage := *cfg
+	stage.observers = nil
+	call := stage
+	call.logger = nil
+
+	results := processAsync(ctx, "MapBatchAsync", batches, &stage, func(ctx context.Context, idx int, b batch[T], ch chan<- Result[batchResult[U]]) {
+		for _, itemIdx := range b.indices {
+			defer cfg.workerStart(itemIdx)()
+		}
+
+		var values []U
+		start := time.Now()
+		err := call.call(ctx, idx, func(ctx context.Context) error {
+			var err error
+			values, err = fn(ctx, b.items)
+			return err
+		})
+		elapsed := time.Since(start)
+
+		// Results are only read back once fn returned, an abandoned call may still be writing them after a timeout
+		result := batchResult[U]{err: err}
+		var batchErr *BatchError
+		if err == nil || errors.As(err, &batchErr) {
+			result = attribute(b, values, err)
+		}
+
+		reportBatch(ctx, cfg, b.indices, result, elapsed)
+		ch <- Result[batchResult[U]]{Value: result}
+	})
+
+	return withAsyncStage(newIterator(func(self *iterator[U], yield func(int, U) bool) {
+		idx := 0
+		for result := range results.Next {
+			for _, value := range result.values {
+				if !yield(idx, value) {
+					return
+				}
+				idx++
+			}
+
+			if result.err != nil {
+				self.err = result.err
+				return
+			}
+		}
+
+		if results.Err() != nil {
+			self.err = results.Err()
+		}
+	}), "MapBatchAsync", cfg, iter)
+}
@@ -0,0 +1,242 @@
+package goiterators_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dreadster3/goiterators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapBatchAsyncCtx(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6, 7}
+	iterator := goiterators.NewIteratorFromSlice(data)
+
+	var mu sync.Mutex
+	var sizes []int
+
+	mapped := goiterators.MapBatchAsyncCtx(context.Background(), iterator, 3, 0, func(ctx context.Context, batch []int) ([]int, error) {
+		mu.Lock()
+		sizes = append(sizes, len(batch))
+		mu.Unlock()
+
+		results := make([]int, len(batch))
+		for i, x := range batch {
+			results[i] = x * 10
+		}
+		return results, nil
+	})
+
+	result := slices.Collect(mapped.Next)
+	slices.Sort(result)
+	slices.Sort(sizes)
+
+	assert.Equal(t, []int{10, 20, 30, 40, 50, 60, 70}, result)
+	assert.NoError(t, mapped.Err())
+	assert.Equal(t, []int{1, 3, 3}, sizes)
+}
+
+func TestMapBatchAsyncCtxOrdered(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	iterator := goiterators.NewIteratorFromSlice(data)
+
+	mapped := goiterators.MapBatchAsyncCtx(context.Background(), iterator, 2, 0, func(ctx context.Context, batch []int) ([]int, error) {
+		// Earlier batches finish last
+		time.Sleep(time.Duration(10-batch[0]) * 5 * time.Millisecond)
+		return batch, nil
+	}, goiterators.WithOrdered())
+
+	assert.Equal(t, data, slices.Collect(mapped.Next))
+	assert.NoError(t, mapped.Err())
+}
+
+func TestMapBatchAsyncCtxMaxWait(t *testing.T) {
+	channel := make(chan int)
+	go func() {
+		defer close(channel)
+		channel <- 1
+		channel <- 2
+		time.Sleep(100 * time.Millisecond)
+		channel <- 3
+	}()
+
+	var mu sync.Mutex
+	var batches [][]int
+
+	mapped := goiterators.MapBatchAsyncCtx(context.Background(), goiterators.NewAsyncIterator(channel), 10, 20*time.Millisecond, func(ctx context.Context, batch []int) ([]int, error) {
+		mu.Lock()
+		batches = append(batches, slices.Clone(batch))
+		mu.Unlock()
+		return batch, nil
+	}, goiterators.WithOrdered())
+
+	assert.Equal(t, []int{1, 2, 3}, slices.Collect(mapped.Next))
+	assert.NoError(t, mapped.Err())
+	assert.Equal(t, [][]int{{1, 2}, {3}}, batches)
+}
+
+func TestMapBatchAsyncCtxPartialFailure(t *testing.T) {
+	errInvalid := errors.New("invalid")
+	data := []int{1, 2, 3, 4}
+	iterator := goiterators.NewIteratorFromSlice(data)
+
+	mapped := goiterators.MapBatchAsyncCtx(context.Background(), iterator, 4, 0, func(ctx context.Context, batch []int) ([]int, error) {
+		return batch, &goiterators.BatchError{Errs: []error{nil, nil, errInvalid, nil}}
+	})
+
+	result := slices.Collect(mapped.Next)
+
+	assert.Equal(t, []int{1, 2, 4}, result)
+	var itemErr *goiterators.ItemError
+	assert.ErrorAs(t, mapped.Err(), &itemErr)
+	assert.Equal(t, 2, itemErr.Index)
+	assert.ErrorIs(t, mapped.Err(), errInvalid)
+}
+
+func TestMapBatchAsyncCtxBatchFailure(t *testing.T) {
+	iterator := goiterators.NewIteratorFromSlice([]int{1, 2})
+
+	mapped := goiterators.MapBatchAsyncCtx(context.Background(), iterator, 2, 0, func(ctx context.Context, batch []int) ([]int, error) {
+		return nil, errors.New("bulk api down")
+	})
+
+	assert.Empty(t, slices.Collect(mapped.Next))
+	assert.EqualError(t, mapped.Err(), "bulk api down")
+}
+
+func TestMapBatchAsyncCtxResultMismatch(t *testing.T) {
+	iterator := goiterators.NewIteratorFromSlice([]int{1, 2})
+
+	mapped := goiterators.MapBatchAsyncCtx(context.Background(), iterator, 2, 0, func(ctx context.Context, batch []int) ([]int, error) {
+		return batch[:1], nil
+	})
+
+	assert.Empty(t, slices.Collect(mapped.Next))
+	assert.EqualError(t, mapped.Err(), "batch function returned 1 results for 2 items")
+}
+
+func TestMapBatchAsyncCtxConcurrency(t *testing.T) {
+	data := make([]int, 20)
+	iterator := goiterators.NewIteratorFromSlice(data)
+
+	var running, maxRunning atomic.Int32
+	mapped := goiterators.MapBatchAsyncCtx(context.Background(), iterator, 2, 0, func(ctx context.Context, batch []int) ([]int, error) {
+		current := running.Add(1)
+		for {
+			peak := maxRunning.Load()
+			if current <= peak || maxRunning.CompareAndSwap(peak, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		running.Add(-1)
+		return batch, nil
+	}, goiterators.WithConcurrency(3))
+
+	assert.Len(t, slices.Collect(mapped.Next), len(data))
+	assert.NoError(t, mapped.Err())
+	assert.LessOrEqual(t, maxRunning.Load(), int32(3))
+}
+
+func TestMapBatchAsyncCtxSourceError(t *testing.T) {
+	next := func(yield func(int, error) bool) {
+		if !yield(1, nil) {
+			return
+		}
+		yield(0, errors.New("source error"))
+	}
+
+	mapped := goiterators.MapBatchAsyncCtx(context.Background(), goiterators.NewIteratorErr(next), 10, 0, func(ctx context.Context, batch []int) ([]int, error) {
+		return batch, nil
+	})
+
+	_ = slices.Collect(mapped.Next)
+	assert.EqualError(t, mapped.Err(), "source error")
+}
+
+func TestMapBatchAsyncCtxCancellation(t *testing.T) {
+	iterator := goiterators.NewIteratorFromSlice([]int{1, 2, 3, 4})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	mapped := goiterators.MapBatchAsyncCtx(ctx, iterator, 2, 0, func(ctx context.Context, batch []int) ([]int, error) {
+		return batch, nil
+	})
+
+	_ = slices.Collect(mapped.Next)
+	assert.ErrorIs(t, mapped.Err(), context.Canceled)
+}
+
+func TestMapBatchAsyncCtxWithObserver(t *testing.T) {
+	errInvalid := errors.New("invalid")
+	observer := &recordingObserver{}
+	iterator := goiterators.NewIteratorFromSlice([]int{0, 1, 2, 3, 4, 5, 6})
+
+	mapped := goiterators.MapBatchAsyncCtx(context.Background(), iterator, 3, 0, func(ctx context.Context, batch []int) ([]int, error) {
+		if slices.Contains(batch, 6) {
+			return batch, &goiterators.BatchError{Errs: []error{errInvalid}}
+		}
+		return batch, nil
+	}, goiterators.WithOrdered(), goiterators.WithObserver(observer))
+
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5}, slices.Collect(mapped.Next))
+	// Wait for every worker to report its stop
+	time.Sleep(20 * time.Millisecond)
+
+	observer.mu.Lock()
+	defer observer.mu.Unlock()
+
+	// Events are reported per item with its input index, not per batch
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6}, observer.in)
+	assert.ElementsMatch(t, []int{0, 1, 2, 3, 4, 5}, observer.out)
+	assert.Equal(t, []int{6}, observer.errs)
+	assert.ElementsMatch(t, []int{0, 1, 2, 3, 4, 5, 6}, observer.workersStarted)
+	assert.ElementsMatch(t, []int{0, 1, 2, 3, 4, 5, 6}, observer.workersStopped)
+}
+
+func TestMapBatchAsyncCtxWithLogger(t *testing.T) {
+	var buffer logBuffer
+	iterator := goiterators.NewIteratorFromSlice([]int{0, 1, 2, 3, 4, 5, 6})
+
+	mapped := goiterators.MapBatchAsyncCtx(context.Background(), iterator, 3, 0, func(ctx context.Context, batch []int) ([]int, error) {
+		if slices.Contains(batch, 6) {
+			return batch, &goiterators.BatchError{Errs: []error{errors.New("invalid")}}
+		}
+		return batch, nil
+	}, goiterators.WithOrdered(), goiterators.WithName("bulk"), goiterators.WithLogger(newTestLogger(&buffer)))
+
+	_ = slices.Collect(mapped.Next)
+	var itemErr *goiterators.ItemError
+	assert.ErrorAs(t, mapped.Err(), &itemErr)
+
+	// The failed item is logged with the same input index as the returned ItemError, not the index of its batch
+	failed := findEntry(logEntries(t, &buffer), "item failed")
+	assert.NotNil(t, failed)
+	assert.Equal(t, "bulk", failed["stage"])
+	assert.Equal(t, float64(itemErr.Index), failed["index"])
+	assert.Equal(t, float64(6), failed["index"])
+}
+
+func TestMapBatchAsyncCtxWithLoggerBatchFailure(t *testing.T) {
+	var buffer logBuffer
+	iterator := goiterators.NewIteratorFromSlice([]int{1, 2})
+
+	mapped := goiterators.MapBatchAsyncCtx(context.Background(), iterator, 2, 0, func(ctx context.Context, batch []int) ([]int, error) {
+		return nil, errors.New("bulk api down")
+	}, goiterators.WithLogger(newTestLogger(&buffer)))
+
+	_ = slices.Collect(mapped.Next)
+
+	entries := logEntries(t, &buffer)
+	assert.Nil(t, findEntry(entries, "item failed"))
+
+	failed := findEntry(entries, "batch failed")
+	assert.NotNil(t, failed)
+	assert.Equal(t, []any{float64(0), float64(1)}, failed["indices"])
+	assert.Equal(t, "bulk api down", failed["error"])
+}
@@ -0,0 +1,213 @@
+package goiterators
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"iter"
+	"os"
+)
+
+// ErrCacheClosed is returned by a CachedIterator ranged over after Close
+var ErrCacheClosed = errors.New("cached iterator closed")
+
+// CachedIterator records the items and the terminal error of an iterator during the first pass and replays them on the next passes
+// A pass stopped early resumes the underlying iterator on the next pass, it is not safe for concurrent passes
+type CachedIterator[T any] struct {
+	next func() (int, T, bool)
+	stop func()
+
+	source Iterator[T]
+	done   bool
+	err    error
+	closed bool
+
+	// indices of every recorded item, the first memLimit items are kept in items and the others spilled to file
+	indices  []int
+	items    []T
+	memLimit int
+
+	codec   Codec[T]
+	tmpDir  string
+	file    *os.File
+	writer  *bufio.Writer
+	encoder Encoder[T]
+
+	info *stageInfo
+}
+
+// Cache records the items of iter in memory during the first pass and replays them on the next passes
+func Cache[T any](iter Iterator[T]) *CachedIterator[T] {
+	return withStage(&CachedIterator[T]{source: iter}, "Cache", nil, iter)
+}
+
+// CacheBounded records the items of iter like Cache, keeping at most memLimit items in memory
+// and spilling the others to a temporary file in tmpDir using codec
+// An empty tmpDir uses the default directory for temporary files, Close removes the file
+func CacheBounded[T any](iter Iterator[T], memLimit int, codec Codec[T], tmpDir string) *CachedIterator[T] {
+	return withStage(&CachedIterator[T]{
+		source:   iter,
+		memLimit: max(memLimit, 1),
+		codec:    codec,
+		tmpDir:   tmpDir,
+	}, "Cache", nil, iter)
+}
+
+func (c *CachedIterator[T]) Next(yield func(T) bool) {
+	c.INext(func(_ int, item T) bool {
+		return yield(item)
+	})
+}
+
+// INext replays the recorded items with their original index, then pulls the remaining items from the underlying iterator
+func (c *CachedIterator[T]) INext(yield func(int, T) bool) {
+	if c.closed {
+		return
+	}
+
+	if !c.replay(yield) {
+		return
+	}
+
+	if c.next == nil && !c.done {
+		c.next, c.stop = iter.Pull2(c.source.INext)
+	}
+
+	for !c.done {
+		idx, item, ok := c.next()
+		if !ok {
+			c.finish(c.source.Err())
+			return
+		}
+
+		if err := c.record(idx, item); err != nil {
+			c.finish(err)
+			return
+		}
+
+		if !yield(idx, item) {
+			return
+		}
+	}
+}
+
+// replay yields the recorded items, it returns false when the pass must stop
+func (c *CachedIterator[T]) replay(yield func(int, T) bool) bool {
+	for i, item := range c.items {
+		if !yield(c.indices[i], item) {
+			return false
+		}
+	}
+
+	spilled := len(c.indices) - len(c.items)
+	if spilled == 0 {
+		return true
+	}
+
+	if err := c.writer.Flush(); err != nil {
+		c.finish(err)
+		return false
+	}
+
+	file, err := os.Open(c.file.Name())
+	if err != nil {
+		c.finish(err)
+		return false
+	}
+	defer file.Close()
+
+	decoder := c.codec.NewDecoder(bufio.NewReader(file))
+	for i := range spilled {
+		item, err := decoder.Decode()
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			c.finish(err)
+			return false
+		}
+
+		if !yield(c.indices[len(c.items)+i], item) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// record stores an item pulled from the underlying iterator, in memory or spilled to file once memory is full
+func (c *CachedIterator[T]) record(idx int, item T) error {
+	if c.codec == nil || len(c.items) < c.memLimit {
+		c.indices = append(c.indices, idx)
+		c.items = append(c.items, item)
+		return nil
+	}
+
+	if c.file == nil {
+		file, err := os.CreateTemp(c.tmpDir, "goiterators-*")
+		if err != nil {
+			return err
+		}
+
+		c.file = file
+		c.writer = bufio.NewWriter(file)
+		c.encoder = c.codec.NewEncoder(c.writer)
+	}
+
+	if err := c.encoder.Encode(item); err != nil {
+		return err
+	}
+
+	c.indices = append(c.indices, idx)
+	return nil
+}
+
+// finish marks the underlying iterator as exhausted with err and releases it
+func (c *CachedIterator[T]) finish(err error) {
+	c.done = true
+	c.err = err
+	if c.stop != nil {
+		c.stop()
+	}
+}
+
+// Err returns the terminal error of the underlying iterator once a pass reached it
+func (c *CachedIterator[T]) Err() error {
+	if c.closed {
+		return ErrCacheClosed
+	}
+
+	return c.err
+}
+
+// Len returns the number of recorded items
+func (c *CachedIterator[T]) Len() int {
+	return len(c.indices)
+}
+
+// SizeHint returns the size hint of the underlying iterator
+func (c *CachedIterator[T]) SizeHint() SizeHint {
+	return SizeHintOf(c.source)
+}
+
+// Close releases the underlying iterator and removes the spill file, the iterator yields no more items afterwards
+func (c *CachedIterator[T]) Close() error {
+	if c.closed {
+		return nil
+	}
+
+	c.closed = true
+	if c.stop != nil {
+		c.stop()
+	}
+	c.indices, c.items = nil, nil
+
+	if c.file == nil {
+		return nil
+	}
+
+	return errors.Join(c.file.Close(), os.Remove(c.file.Name()))
+}
+
+func (c *CachedIterator[T]) stage() *stageInfo        { return c.info }
+func (c *CachedIterator[T]) setStage(info *stageInfo) { c.info = info }
@@ -0,0 +1,143 @@
+package goiterators_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dreadster3/goiterators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheReplay(t *testing.T) {
+	var calls atomic.Int64
+	mapped := goiterators.MapAsyncCtx(context.Background(), goiterators.NewIteratorFromSlice([]int{1, 2, 3}), func(ctx context.Context, x int) (int, error) {
+		calls.Add(1)
+		return x * 10, nil
+	}, goiterators.WithOrdered())
+
+	cached := goiterators.Cache(mapped)
+	defer cached.Close()
+
+	for range 3 {
+		assert.Equal(t, []int{10, 20, 30}, slices.Collect(cached.Next))
+		assert.NoError(t, cached.Err())
+	}
+	assert.Equal(t, int64(3), calls.Load())
+	assert.Equal(t, 3, cached.Len())
+}
+
+func TestCacheKeepsIndices(t *testing.T) {
+	filtered := goiterators.Filter(goiterators.NewIteratorFromSlice([]int{1, 2, 3, 4}), func(x int) bool { return x%2 == 0 })
+	cached := goiterators.Cache(filtered)
+	defer cached.Close()
+
+	for range 2 {
+		indices, items := collectIndexed[int](cached)
+		assert.Equal(t, []int{2, 4}, items)
+		assert.Equal(t, []int{1, 3}, indices)
+	}
+}
+
+func TestCacheReplaysError(t *testing.T) {
+	pulls := 0
+	next := func(yield func(int, error) bool) {
+		pulls++
+		if !yield(1, nil) {
+			return
+		}
+		yield(0, errors.New("source error"))
+	}
+
+	cached := goiterators.Cache(goiterators.NewIteratorErr(next))
+	defer cached.Close()
+
+	for range 2 {
+		assert.Equal(t, []int{1}, slices.Collect(cached.Next))
+		assert.EqualError(t, cached.Err(), "source error")
+	}
+	assert.Equal(t, 1, pulls)
+}
+
+func TestCacheResumesAfterEarlyStop(t *testing.T) {
+	var pulled []int
+	source := goiterators.Tap(goiterators.NewIteratorFromSlice([]int{1, 2, 3, 4}), func(x int) {
+		pulled = append(pulled, x)
+	})
+
+	cached := goiterators.Cache(source)
+	defer cached.Close()
+
+	assert.Equal(t, []int{1, 2}, slices.Collect(goiterators.Take(cached, 2).Next))
+	assert.Equal(t, []int{1, 2, 3, 4}, slices.Collect(cached.Next))
+	assert.Equal(t, []int{1, 2, 3, 4}, slices.Collect(cached.Next))
+	assert.Equal(t, []int{1, 2, 3, 4}, pulled)
+}
+
+func TestCacheBounded(t *testing.T) {
+	codecs := map[string]goiterators.Codec[record]{
+		"gob":  goiterators.GobCodec[record](),
+		"json": goiterators.JSONCodec[record](),
+	}
+
+	for name, codec := range codecs {
+		t.Run(name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			data := []record{{1, "a"}, {2, "b"}, {3, "c"}, {4, "d"}, {5, "e"}}
+
+			cached := goiterators.CacheBounded(goiterators.NewIteratorFromSlice(data), 2, codec, tmpDir)
+
+			// Stop in the spilled part, then resume and replay twice
+			assert.Equal(t, data[:4], slices.Collect(goiterators.Take(cached, 4).Next))
+			for range 2 {
+				indices, items := collectIndexed[record](cached)
+				assert.Equal(t, data, items)
+				assert.Equal(t, []int{0, 1, 2, 3, 4}, indices)
+				assert.NoError(t, cached.Err())
+			}
+
+			entries, err := os.ReadDir(tmpDir)
+			assert.NoError(t, err)
+			assert.Len(t, entries, 1, "Expected items above the memory limit to be spilled")
+
+			assert.NoError(t, cached.Close())
+
+			entries, err = os.ReadDir(tmpDir)
+			assert.NoError(t, err)
+			assert.Empty(t, entries, "Expected the spill file to be removed")
+		})
+	}
+}
+
+func TestCacheBoundedInvalidDir(t *testing.T) {
+	cached := goiterators.CacheBounded(goiterators.NewIteratorFromSlice([]int{1, 2, 3}), 1, goiterators.GobCodec[int](), "/nonexistent/goiterators")
+	defer cached.Close()
+
+	assert.Equal(t, []int{1}, slices.Collect(cached.Next))
+	assert.Error(t, cached.Err())
+}
+
+func TestCacheClose(t *testing.T) {
+	cached := goiterators.Cache(goiterators.NewIteratorFromSlice([]int{1, 2, 3}))
+
+	assert.Equal(t, []int{1}, slices.Collect(goiterators.Take(cached, 1).Next))
+	assert.NoError(t, cached.Close())
+	assert.NoError(t, cached.Close())
+
+	assert.Empty(t, slices.Collect(cached.Next))
+	assert.ErrorIs(t, cached.Err(), goiterators.ErrCacheClosed)
+}
+
+func TestCacheDescribe(t *testing.T) {
+	cached := goiterators.Cache(goiterators.NewIteratorFromSlice([]int{1}))
+	defer cached.Close()
+
+	assert.Equal(t, []goiterators.Stage{
+		{ID: 0, Kind: "Slice"},
+		{ID: 1, Kind: "Cache", Inputs: []int{0}},
+	}, goiterators.Describe[int](cached).Stages)
+	assert.Equal(t, goiterators.ExactSize(1), goiterators.SizeHintOf[int](cached))
+}
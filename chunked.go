@@ -0,0 +1,103 @@
+package goiterators
+
+import (
+	"context"
+	"runtime/pprof"
+	"sync"
+)
+
+// maxChunkSize bounds the number of items a worker claims at once, and so the results buffered per chunk in ordered mode
+const maxChunkSize = 256
+
+// chunkSize splits n items into several chunks per worker so faster workers pick up the remaining work
+func chunkSize(n, workers int) int {
+	return min(max(n/(workers*4), 1), maxChunkSize)
+}
+
+// processChunked runs the worker on the items of a slice with a fixed pool of goroutines claiming ranges of items,
+// instead of spawning a goroutine per item
+// Results of a chunk are buffered so ordered output does not serialize the workers
+func processChunked[T, U any](ctx context.Context, source *sliceSource[T], cfg *config, output *asyncOutput[U], worker func(context.Context, int, T, chan<- Result[U])) {
+	n := source.len()
+	workers := min(cfg.concurrency, max(n, 1))
+	size := chunkSize(n, workers)
+
+	// Chunks and their output slots are claimed in input order
+	var mu sync.Mutex
+	next := 0
+	claim := func() (int, int, chan<- Result[U], func(), bool) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if next >= n || ctx.Err() != nil {
+			return 0, 0, nil, nil, false
+		}
+
+		from, to := next, min(next+size, n)
+		next = to
+		channel, done := output.reserve(to - from)
+		return from, to, channel, done, true
+	}
+
+	// run processes the item at position i, it returns false once the stage must stop
+	run := func(i int, channel chan<- Result[U]) bool {
+		idx, item := source.at(i)
+		cfg.itemIn(idx)
+
+		if cfg.limiter != nil {
+			if err := cfg.limiter.Wait(ctx); err != nil {
+				return false
+			}
+		}
+		if ctx.Err() != nil {
+			return false
+		}
+
+		defer cfg.workerStart(idx)()
+		defer func() {
+			if r := recover(); r != nil {
+				err := newPanicError(r)
+				cfg.logItemError(ctx, idx, err)
+				channel <- Result[U]{Value: *new(U), Err: err}
+			}
+		}()
+
+		worker(ctx, idx, item, channel)
+		return true
+	}
+
+	go func() {
+		pprof.SetGoroutineLabels(ctx)
+		defer output.close()
+
+		finish := cfg.logStageStart()
+		defer func() { finish(min(next, n)) }()
+
+		var wg sync.WaitGroup
+		for range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					from, to, channel, done, ok := claim()
+					if !ok {
+						return
+					}
+
+					for i := from; i < to; i++ {
+						if !run(i, channel) {
+							break
+						}
+					}
+					done()
+				}
+			}()
+		}
+		wg.Wait()
+
+		if ctx.Err() != nil {
+			cfg.logStageError(ctx.Err())
+			output.send(Result[U]{Value: *new(U), Err: ctx.Err()})
+		}
+	}()
+}
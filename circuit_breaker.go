@@ -0,0 +1,247 @@
+package goiterators
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned instead of calling the item function while the circuit breaker is open
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState is the state of a circuit breaker
+type CircuitState int
+
+const (
+	// CircuitClosed lets every call through while tracking the failure rate
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects every call until the cooldown has elapsed
+	CircuitOpen
+	// CircuitHalfOpen lets a limited number of trial calls through to probe for recovery
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreakerConfig configures when a circuit breaker opens and how it recovers
+type CircuitBreakerConfig struct {
+	// WindowSize is the number of most recent calls used to compute the failure rate, defaults to 20
+	WindowSize int
+	// MinRequests is the number of calls required in the window before the breaker may open, defaults to WindowSize
+	MinRequests int
+	// FailureRate opens the breaker once the share of failed calls in the window reaches it, defaults to 0.5
+	FailureRate float64
+	// Cooldown is how long the breaker stays open before allowing trial calls, defaults to 30 seconds
+	Cooldown time.Duration
+	// HalfOpenRequests is the number of trial calls that must succeed to close the breaker again, defaults to 1
+	HalfOpenRequests int
+	// IsFailure reports whether an error counts as a failure, nil counts every error except context cancellation
+	IsFailure func(error) bool
+}
+
+// CircuitBreakerStats is a snapshot of a circuit breaker for metrics
+type CircuitBreakerStats struct {
+	State     CircuitState
+	Successes uint64
+	Failures  uint64
+	Rejected  uint64
+}
+
+// CircuitBreaker short-circuits item functions while a downstream dependency is failing
+// A single breaker can be shared between several stages guarding the same dependency
+type CircuitBreaker struct {
+	mu     sync.Mutex
+	config CircuitBreakerConfig
+
+	state      CircuitState
+	generation uint64
+	openedAt   time.Time
+
+	window   []bool
+	pos      int
+	count    int
+	failures int
+
+	halfOpenInFlight  int
+	halfOpenSuccesses int
+
+	stats CircuitBreakerStats
+}
+
+// NewCircuitBreaker creates a closed circuit breaker, filling unset config fields with defaults
+func NewCircuitBreaker(config CircuitBreakerConfig) *CircuitBreaker {
+	if config.WindowSize <= 0 {
+		config.WindowSize = 20
+	}
+	if config.MinRequests <= 0 || config.MinRequests > config.WindowSize {
+		config.MinRequests = config.WindowSize
+	}
+	if config.FailureRate <= 0 || config.FailureRate > 1 {
+		config.FailureRate = 0.5
+	}
+	if config.Cooldown <= 0 {
+		config.Cooldown = 30 * time.Second
+	}
+	if config.HalfOpenRequests <= 0 {
+		config.HalfOpenRequests = 1
+	}
+	if config.IsFailure == nil {
+		config.IsFailure = func(err error) bool {
+			return !errors.Is(err, context.Canceled)
+		}
+	}
+
+	return &CircuitBreaker{
+		config: config,
+		window: make([]bool, config.WindowSize),
+	}
+}
+
+// WithCircuitBreaker guards item functions with the circuit breaker, failing items with ErrCircuitOpen while it is open
+func WithCircuitBreaker(cb *CircuitBreaker) Option {
+	return func(c *config) {
+		c.breaker = cb
+	}
+}
+
+// State returns the current state of the circuit breaker
+func (cb *CircuitBreaker) State() CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.advance(time.Now())
+	return cb.state
+}
+
+// Stats returns a snapshot of the circuit breaker counters
+func (cb *CircuitBreaker) Stats() CircuitBreakerStats {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.advance(time.Now())
+	stats := cb.stats
+	stats.State = cb.state
+	return stats
+}
+
+// advance moves an open breaker to half-open once the cooldown has elapsed
+func (cb *CircuitBreaker) advance(now time.Time) {
+	if cb.state == CircuitOpen && now.Sub(cb.openedAt) >= cb.config.Cooldown {
+		cb.transition(CircuitHalfOpen, now)
+	}
+}
+
+// transition switches to state, discarding the outcome of calls started in the previous state
+func (cb *CircuitBreaker) transition(state CircuitState, now time.Time) {
+	cb.state = state
+	cb.generation++
+	cb.halfOpenInFlight = 0
+	cb.halfOpenSuccesses = 0
+
+	switch state {
+	case CircuitOpen:
+		cb.openedAt = now
+	case CircuitClosed:
+		clear(cb.window)
+		cb.pos = 0
+		cb.count = 0
+		cb.failures = 0
+	}
+}
+
+// allow reports whether a call may proceed and the generation it belongs to
+func (cb *CircuitBreaker) allow() (uint64, bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.advance(time.Now())
+
+	switch cb.state {
+	case CircuitOpen:
+		cb.stats.Rejected++
+		return 0, false
+	case CircuitHalfOpen:
+		if cb.halfOpenInFlight >= cb.config.HalfOpenRequests {
+			cb.stats.Rejected++
+			return 0, false
+		}
+		cb.halfOpenInFlight++
+	}
+
+	return cb.generation, true
+}
+
+// record registers the outcome of a call allowed in the given generation
+func (cb *CircuitBreaker) record(generation uint64, err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	failed := err != nil && cb.config.IsFailure(err)
+	if failed {
+		cb.stats.Failures++
+	} else {
+		cb.stats.Successes++
+	}
+
+	if generation != cb.generation {
+		return
+	}
+
+	now := time.Now()
+	switch cb.state {
+	case CircuitHalfOpen:
+		if failed {
+			cb.transition(CircuitOpen, now)
+			return
+		}
+
+		cb.halfOpenSuccesses++
+		if cb.halfOpenSuccesses >= cb.config.HalfOpenRequests {
+			cb.transition(CircuitClosed, now)
+		}
+	case CircuitClosed:
+		if cb.count == len(cb.window) {
+			if cb.window[cb.pos] {
+				cb.failures--
+			}
+		} else {
+			cb.count++
+		}
+
+		cb.window[cb.pos] = failed
+		cb.pos = (cb.pos + 1) % len(cb.window)
+		if failed {
+			cb.failures++
+		}
+
+		if cb.count >= cb.config.MinRequests && float64(cb.failures) >= cb.config.FailureRate*float64(cb.count) {
+			cb.transition(CircuitOpen, now)
+		}
+	}
+}
+
+// wrap returns a callFunc guarded by the circuit breaker
+func (cb *CircuitBreaker) wrap(fn callFunc) callFunc {
+	return func(ctx context.Context) error {
+		generation, ok := cb.allow()
+		if !ok {
+			return ErrCircuitOpen
+		}
+
+		err := fn(ctx)
+		cb.record(generation, err)
+		return err
+	}
+}
@@ -0,0 +1,128 @@
+package goiterators_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dreadster3/goiterators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreakerOpensOnFailureRate(t *testing.T) {
+	cb := goiterators.NewCircuitBreaker(goiterators.CircuitBreakerConfig{
+		WindowSize:  4,
+		FailureRate: 0.5,
+		Cooldown:    time.Hour,
+	})
+
+	var calls atomic.Int32
+	failing := func(ctx context.Context, x int) error {
+		calls.Add(1)
+		return errors.New("downstream unavailable")
+	}
+
+	// Sequential processing so every call observes the previous outcome
+	for i := range 10 {
+		err := goiterators.ForEachAsyncCtx(context.Background(), goiterators.NewIteratorFromSlice([]int{i}), failing, goiterators.WithCircuitBreaker(cb))
+		assert.Error(t, err)
+	}
+
+	assert.Equal(t, int32(4), calls.Load())
+	assert.Equal(t, goiterators.CircuitOpen, cb.State())
+
+	err := goiterators.ForEachAsyncCtx(context.Background(), goiterators.NewIteratorFromSlice([]int{1}), failing, goiterators.WithCircuitBreaker(cb))
+	assert.ErrorIs(t, err, goiterators.ErrCircuitOpen)
+
+	stats := cb.Stats()
+	assert.Equal(t, goiterators.CircuitOpen, stats.State)
+	assert.Equal(t, uint64(4), stats.Failures)
+	assert.Equal(t, uint64(7), stats.Rejected)
+}
+
+func TestCircuitBreakerHalfOpenRecovery(t *testing.T) {
+	cb := goiterators.NewCircuitBreaker(goiterators.CircuitBreakerConfig{
+		WindowSize: 2,
+		Cooldown:   20 * time.Millisecond,
+	})
+
+	var healthy atomic.Bool
+	fn := func(ctx context.Context, x int) (int, error) {
+		if !healthy.Load() {
+			return 0, errors.New("downstream unavailable")
+		}
+		return x, nil
+	}
+
+	for i := range 2 {
+		mapped := goiterators.MapAsyncCtx(context.Background(), goiterators.NewIteratorFromSlice([]int{i}), fn, goiterators.WithCircuitBreaker(cb))
+		_ = slices.Collect(mapped.Next)
+	}
+	assert.Equal(t, goiterators.CircuitOpen, cb.State())
+
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, goiterators.CircuitHalfOpen, cb.State())
+
+	// A failing trial call opens the breaker again
+	mapped := goiterators.MapAsyncCtx(context.Background(), goiterators.NewIteratorFromSlice([]int{1}), fn, goiterators.WithCircuitBreaker(cb))
+	_ = slices.Collect(mapped.Next)
+	assert.Equal(t, goiterators.CircuitOpen, cb.State())
+
+	time.Sleep(30 * time.Millisecond)
+	healthy.Store(true)
+
+	// A successful trial call closes it
+	mapped = goiterators.MapAsyncCtx(context.Background(), goiterators.NewIteratorFromSlice([]int{7}), fn, goiterators.WithCircuitBreaker(cb))
+	assert.Equal(t, []int{7}, slices.Collect(mapped.Next))
+	assert.NoError(t, mapped.Err())
+	assert.Equal(t, goiterators.CircuitClosed, cb.State())
+}
+
+func TestCircuitBreakerIsFailure(t *testing.T) {
+	errIgnored := errors.New("not found")
+	cb := goiterators.NewCircuitBreaker(goiterators.CircuitBreakerConfig{
+		WindowSize: 1,
+		IsFailure: func(err error) bool {
+			return !errors.Is(err, errIgnored)
+		},
+	})
+
+	for range 3 {
+		err := goiterators.ForEachAsyncCtx(context.Background(), goiterators.NewIteratorFromSlice([]int{1}), func(ctx context.Context, x int) error {
+			return errIgnored
+		}, goiterators.WithCircuitBreaker(cb))
+		assert.ErrorIs(t, err, errIgnored)
+	}
+
+	assert.Equal(t, goiterators.CircuitClosed, cb.State())
+	assert.Equal(t, uint64(3), cb.Stats().Successes)
+}
+
+func TestCircuitBreakerWithRetry(t *testing.T) {
+	cb := goiterators.NewCircuitBreaker(goiterators.CircuitBreakerConfig{
+		WindowSize: 2,
+		Cooldown:   time.Hour,
+	})
+
+	var calls atomic.Int32
+	err := goiterators.ForEachAsyncCtx(context.Background(), goiterators.NewIteratorFromSlice([]int{1}), func(ctx context.Context, x int) error {
+		calls.Add(1)
+		return errors.New("downstream unavailable")
+	}, goiterators.WithCircuitBreaker(cb), goiterators.WithRetry(goiterators.RetryPolicy{MaxAttempts: 10}))
+
+	// Retries stop as soon as the breaker opens
+	var retryErr *goiterators.RetryError
+	assert.ErrorAs(t, err, &retryErr)
+	assert.ErrorIs(t, err, goiterators.ErrCircuitOpen)
+	assert.Equal(t, 3, retryErr.Attempts)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestCircuitStateString(t *testing.T) {
+	assert.Equal(t, "closed", goiterators.CircuitClosed.String())
+	assert.Equal(t, "open", goiterators.CircuitOpen.String())
+	assert.Equal(t, "half-open", goiterators.CircuitHalfOpen.String())
+}
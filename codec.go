@@ -0,0 +1,89 @@
+package goiterators
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"io"
+)
+
+// Encoder writes items to an underlying stream
+type Encoder[T any] interface {
+	Encode(item T) error
+}
+
+// Decoder reads items from an underlying stream, returning io.EOF once the stream is exhausted
+type Decoder[T any] interface {
+	Decode() (T, error)
+}
+
+// Codec creates encoders and decoders used to spill items to disk
+type Codec[T any] interface {
+	NewEncoder(w io.Writer) Encoder[T]
+	NewDecoder(r io.Reader) Decoder[T]
+}
+
+type gobCodec[T any] struct{}
+
+// GobCodec creates a Codec using encoding/gob
+func GobCodec[T any]() Codec[T] {
+	return gobCodec[T]{}
+}
+
+func (gobCodec[T]) NewEncoder(w io.Writer) Encoder[T] {
+	return gobEncoder[T]{encoder: gob.NewEncoder(w)}
+}
+
+func (gobCodec[T]) NewDecoder(r io.Reader) Decoder[T] {
+	return gobDecoder[T]{decoder: gob.NewDecoder(r)}
+}
+
+type gobEncoder[T any] struct {
+	encoder *gob.Encoder
+}
+
+func (e gobEncoder[T]) Encode(item T) error {
+	return e.encoder.Encode(&item)
+}
+
+type gobDecoder[T any] struct {
+	decoder *gob.Decoder
+}
+
+func (d gobDecoder[T]) Decode() (T, error) {
+	var item T
+	err := d.decoder.Decode(&item)
+	return item, err
+}
+
+type jsonCodec[T any] struct{}
+
+// JSONCodec creates a Codec using encoding/json
+func JSONCodec[T any]() Codec[T] {
+	return jsonCodec[T]{}
+}
+
+func (jsonCodec[T]) NewEncoder(w io.Writer) Encoder[T] {
+	return jsonEncoder[T]{encoder: json.NewEncoder(w)}
+}
+
+func (jsonCodec[T]) NewDecoder(r io.Reader) Decoder[T] {
+	return jsonDecoder[T]{decoder: json.NewDecoder(r)}
+}
+
+type jsonEncoder[T any] struct {
+	encoder *json.Encoder
+}
+
+func (e jsonEncoder[T]) Encode(item T) error {
+	return e.encoder.Encode(item)
+}
+
+type jsonDecoder[T any] struct {
+	decoder *json.Decoder
+}
+
+func (d jsonDecoder[T]) Decode() (T, error) {
+	var item T
+	err := d.decoder.Decode(&item)
+	return item, err
+}
@@ -0,0 +1,225 @@
+package goiterators
+
+import (
+	"fmt"
+	"strings"
+)
+
+// stageInfo records how an iterator was built so Describe can walk the pipeline
+type stageInfo struct {
+	kind        string
+	name        string
+	async       bool
+	concurrency int
+	ordered     bool
+	inputs      []any
+}
+
+// describer is implemented by the iterators of this package
+type describer interface {
+	stage() *stageInfo
+	setStage(info *stageInfo)
+}
+
+func (it *iterator[T]) stage() *stageInfo             { return it.info }
+func (it *iterator[T]) setStage(info *stageInfo)      { it.info = info }
+func (it *asyncIterator[T]) stage() *stageInfo        { return it.info }
+func (it *asyncIterator[T]) setStage(info *stageInfo) { it.info = info }
+
+// withStage records the kind, name and inputs of the stage producing it, cfg may be nil for stages without options
+func withStage[I any](it I, kind string, cfg *config, inputs ...any) I {
+	info := &stageInfo{kind: kind, inputs: inputs}
+	if cfg != nil {
+		info.name = cfg.name
+	}
+
+	if d, ok := any(it).(describer); ok {
+		d.setStage(info)
+	}
+
+	return it
+}
+
+// withAsyncStage records the stage producing it like withStage, along with its concurrency settings
+func withAsyncStage[I any](it I, kind string, cfg *config, inputs ...any) I {
+	it = withStage(it, kind, cfg, inputs...)
+	if d, ok := any(it).(describer); ok {
+		info := d.stage()
+		info.async = true
+		info.concurrency = cfg.concurrency
+		info.ordered = cfg.ordered
+	}
+
+	return it
+}
+
+// inputsOf converts iterators to stage inputs
+func inputsOf[T any](its []Iterator[T]) []any {
+	inputs := make([]any, len(its))
+	for i, it := range its {
+		inputs[i] = it
+	}
+
+	return inputs
+}
+
+// Named names the stage producing iter, for stages that do not accept WithName
+// Iterators from outside this package are wrapped in a stage carrying the name
+func Named[T any](iter Iterator[T], name string) Iterator[T] {
+	if d, ok := iter.(describer); ok && d.stage() != nil {
+		info := *d.stage()
+		info.name = name
+		d.setStage(&info)
+		return iter
+	}
+
+	named := withSize(withStage(newIterator(func(self *iterator[T], yield func(int, T) bool) {
+		for idx, item := range iter.INext {
+			if !yield(idx, item) {
+				return
+			}
+		}
+
+		if iter.Err() != nil {
+			self.err = iter.Err()
+		}
+	}), "Iterator", nil, iter), SizeHintOf(iter))
+	named.info.name = name
+
+	return named
+}
+
+// Stage describes a stage of a pipeline
+type Stage struct {
+	// ID identifies the stage within its Pipeline
+	ID int
+	// Kind is the algorithm of the stage, such as Map or FilterAsync, or Iterator for iterators from outside this package
+	Kind string
+	// Name is the name given with WithName or Named
+	Name string
+	// Async reports whether the stage processes items in parallel
+	Async bool
+	// Concurrency is the worker limit of an async stage, zero spawns a worker per item
+	Concurrency int
+	// Ordered reports whether an async stage keeps the input order
+	Ordered bool
+	// Inputs are the IDs of the stages feeding this stage
+	Inputs []int
+}
+
+// label returns the kind and name of the stage
+func (s Stage) label() string {
+	if s.Name == "" {
+		return s.Kind
+	}
+
+	return fmt.Sprintf("%s %q", s.Kind, s.Name)
+}
+
+// settings returns the concurrency settings of an async stage
+func (s Stage) settings() string {
+	if !s.Async {
+		return ""
+	}
+
+	settings := "concurrency=unbounded"
+	if s.Concurrency > 0 {
+		settings = fmt.Sprintf("concurrency=%d", s.Concurrency)
+	}
+	if s.Ordered {
+		settings += ", ordered"
+	}
+
+	return settings
+}
+
+// Pipeline is the graph of stages producing an iterator, inputs come before the stages consuming them and the last stage is the described iterator
+type Pipeline struct {
+	Stages []Stage
+}
+
+// Describe walks the iterators wrapped by iter and returns the graph of their stages
+func Describe[T any](iter Iterator[T]) Pipeline {
+	var pipeline Pipeline
+	ids := make(map[describer]int)
+
+	var visit func(input any) int
+	visit = func(input any) int {
+		d, ok := input.(describer)
+		if !ok || d.stage() == nil {
+			pipeline.Stages = append(pipeline.Stages, Stage{ID: len(pipeline.Stages), Kind: "Iterator"})
+			return len(pipeline.Stages) - 1
+		}
+
+		if id, ok := ids[d]; ok {
+			return id
+		}
+
+		info := d.stage()
+		stage := Stage{
+			Kind:        info.kind,
+			Name:        info.name,
+			Async:       info.async,
+			Concurrency: info.concurrency,
+			Ordered:     info.ordered,
+		}
+		for _, input := range info.inputs {
+			stage.Inputs = append(stage.Inputs, visit(input))
+		}
+
+		stage.ID = len(pipeline.Stages)
+		ids[d] = stage.ID
+		pipeline.Stages = append(pipeline.Stages, stage)
+		return stage.ID
+	}
+	visit(iter)
+
+	return pipeline
+}
+
+// String renders the pipeline as plain text, one stage per line
+func (p Pipeline) String() string {
+	var builder strings.Builder
+	for _, stage := range p.Stages {
+		fmt.Fprintf(&builder, "[%d] %s", stage.ID, stage.label())
+		if settings := stage.settings(); settings != "" {
+			fmt.Fprintf(&builder, " (%s)", settings)
+		}
+		if len(stage.Inputs) > 0 {
+			inputs := make([]string, len(stage.Inputs))
+			for i, input := range stage.Inputs {
+				inputs[i] = fmt.Sprint(input)
+			}
+			fmt.Fprintf(&builder, " <- %s", strings.Join(inputs, ", "))
+		}
+		builder.WriteString("\n")
+	}
+
+	return builder.String()
+}
+
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// DOT renders the pipeline as a Graphviz digraph with data flowing from left to right
+func (p Pipeline) DOT() string {
+	var builder strings.Builder
+	builder.WriteString("digraph pipeline {\n\trankdir=LR;\n\tnode [shape=box];\n")
+	for _, stage := range p.Stages {
+		lines := []string{stage.Kind}
+		if stage.Name != "" {
+			lines = append(lines, stage.Name)
+		}
+		if settings := stage.settings(); settings != "" {
+			lines = append(lines, settings)
+		}
+		fmt.Fprintf(&builder, "\ts%d [label=\"%s\"];\n", stage.ID, dotEscaper.Replace(strings.Join(lines, "\n")))
+	}
+	for _, stage := range p.Stages {
+		for _, input := range stage.Inputs {
+			fmt.Fprintf(&builder, "\ts%d -> s%d;\n", input, stage.ID)
+		}
+	}
+	builder.WriteString("}\n")
+
+	return builder.String()
+}
@@ -0,0 +1,132 @@
+package goiterators_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/dreadster3/goiterators"
+	"github.com/stretchr/testify/assert"
+)
+
+type countdown struct {
+	from int
+	err  error
+}
+
+func (c *countdown) Next(yield func(int) bool) {
+	for i := c.from; i > 0; i-- {
+		if !yield(i) {
+			return
+		}
+	}
+}
+
+func (c *countdown) INext(yield func(int, int) bool) {
+	for i := c.from; i > 0; i-- {
+		if !yield(c.from-i, i) {
+			return
+		}
+	}
+}
+
+func (c *countdown) Err() error {
+	return c.err
+}
+
+func TestDescribe(t *testing.T) {
+	source := goiterators.NewIteratorFromSlice([]int{1, 2, 3, 4})
+	filtered := goiterators.Filter(source, func(x int) bool { return x%2 == 0 }, goiterators.WithName("evens"))
+	mapped := goiterators.MapAsyncCtx(context.Background(), filtered, func(ctx context.Context, x int) (int, error) {
+		return x * 10, nil
+	}, goiterators.WithName("scale"), goiterators.WithConcurrency(4), goiterators.WithOrdered())
+	taken := goiterators.Take(mapped, 1)
+
+	pipeline := goiterators.Describe(taken)
+
+	assert.Equal(t, []goiterators.Stage{
+		{ID: 0, Kind: "Slice"},
+		{ID: 1, Kind: "Filter", Name: "evens", Inputs: []int{0}},
+		{ID: 2, Kind: "MapAsync", Name: "scale", Async: true, Concurrency: 4, Ordered: true, Inputs: []int{1}},
+		{ID: 3, Kind: "Take", Inputs: []int{2}},
+	}, pipeline.Stages)
+
+	// Describing does not consume the pipeline
+	assert.Equal(t, []int{20}, slices.Collect(taken.Next))
+}
+
+func TestDescribeSharedInput(t *testing.T) {
+	source := goiterators.NewIteratorFromSlice([]int{1, 2, 3})
+	cmp := func(a, b int) int { return a - b }
+
+	union := goiterators.Union(source, source, cmp)
+	pipeline := goiterators.Describe(union)
+
+	assert.Equal(t, []goiterators.Stage{
+		{ID: 0, Kind: "Slice"},
+		{ID: 1, Kind: "Union", Inputs: []int{0, 0}},
+	}, pipeline.Stages)
+}
+
+func TestDescribeMultipleInputs(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	merged := goiterators.MergeSorted(less,
+		goiterators.NewIteratorFromSlice([]int{1}),
+		goiterators.Named(goiterators.NewIteratorFromSlice([]int{2}), "second"),
+		&countdown{from: 3},
+	)
+
+	pipeline := goiterators.Describe(merged)
+
+	assert.Equal(t, []goiterators.Stage{
+		{ID: 0, Kind: "Slice"},
+		{ID: 1, Kind: "Slice", Name: "second"},
+		{ID: 2, Kind: "Iterator"},
+		{ID: 3, Kind: "MergeSorted", Inputs: []int{0, 1, 2}},
+	}, pipeline.Stages)
+}
+
+func TestNamedForeignIterator(t *testing.T) {
+	named := goiterators.Named[int](&countdown{from: 3}, "countdown")
+
+	assert.Equal(t, []goiterators.Stage{
+		{ID: 0, Kind: "Iterator"},
+		{ID: 1, Kind: "Iterator", Name: "countdown", Inputs: []int{0}},
+	}, goiterators.Describe(named).Stages)
+	assert.Equal(t, []int{3, 2, 1}, slices.Collect(named.Next))
+}
+
+func TestNamedRenamesStage(t *testing.T) {
+	source := goiterators.NewIteratorFromSlice([]int{1})
+	named := goiterators.Named(source, "input")
+
+	assert.Equal(t, "input", goiterators.Describe(named).Stages[0].Name)
+	assert.Equal(t, "input", goiterators.Describe(source).Stages[0].Name)
+}
+
+func TestPipelineString(t *testing.T) {
+	source := goiterators.NewIteratorFromSlice([]int{1, 2, 3})
+	mapped := goiterators.MapAsync(source, func(x int) int { return x }, goiterators.WithName("double"), goiterators.WithOrdered())
+	filtered := goiterators.Filter(mapped, func(x int) bool { return true })
+
+	expected := "[0] Slice\n" +
+		"[1] MapAsync \"double\" (concurrency=unbounded, ordered) <- 0\n" +
+		"[2] Filter <- 1\n"
+
+	assert.Equal(t, expected, goiterators.Describe(filtered).String())
+}
+
+func TestPipelineDOT(t *testing.T) {
+	source := goiterators.NewIteratorFromSlice([]int{1, 2, 3})
+	mapped := goiterators.MapAsync(source, func(x int) int { return x }, goiterators.WithName(`say "hi"`), goiterators.WithConcurrency(2))
+
+	expected := "digraph pipeline {\n" +
+		"\trankdir=LR;\n" +
+		"\tnode [shape=box];\n" +
+		"\ts0 [label=\"Slice\"];\n" +
+		"\ts1 [label=\"MapAsync\\nsay \\\"hi\\\"\\nconcurrency=2\"];\n" +
+		"\ts0 -> s1;\n" +
+		"}\n"
+
+	assert.Equal(t, expected, goiterators.Describe(mapped).DOT())
+}
@@ -0,0 +1,101 @@
+package goiterators
+
+import "cmp"
+
+// ChangeKind describes how an item differs between two snapshots
+type ChangeKind int
+
+const (
+	// ChangeAdded marks an item only present in the new snapshot
+	ChangeAdded ChangeKind = iota
+	// ChangeRemoved marks an item only present in the old snapshot
+	ChangeRemoved
+	// ChangeModified marks an item present in both snapshots with different contents
+	ChangeModified
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case ChangeAdded:
+		return "added"
+	case ChangeRemoved:
+		return "removed"
+	case ChangeModified:
+		return "modified"
+	default:
+		return "unknown"
+	}
+}
+
+// Change is a difference between two snapshots, Old is unset for additions and New is unset for removals
+type Change[T any] struct {
+	Kind ChangeKind
+	Old  T
+	New  T
+}
+
+// DiffSorted compares an old and a new snapshot, both sorted by key, and yields the items added, removed or modified
+// Items with the same key are compared with equal and unchanged items are skipped
+func DiffSorted[T any, K cmp.Ordered](left, right Iterator[T], key func(T) K, equal func(a, b T) bool) Iterator[Change[T]] {
+	return withStage(newIterator(func(self *iterator[Change[T]], yield func(int, Change[T]) bool) {
+		l := newCursor(left)
+		defer l.stop()
+		r := newCursor(right)
+		defer r.stop()
+
+		idx := 0
+		emit := func(change Change[T]) bool {
+			if !yield(idx, change) {
+				return false
+			}
+			idx++
+			return true
+		}
+
+		for {
+			if err := l.err(); err != nil {
+				self.err = err
+				return
+			}
+			if err := r.err(); err != nil {
+				self.err = err
+				return
+			}
+
+			switch {
+			case !l.ok && !r.ok:
+				return
+			case !r.ok:
+				if !emit(Change[T]{Kind: ChangeRemoved, Old: l.item}) {
+					return
+				}
+				l.advance()
+			case !l.ok:
+				if !emit(Change[T]{Kind: ChangeAdded, New: r.item}) {
+					return
+				}
+				r.advance()
+			default:
+				c := cmp.Compare(key(l.item), key(r.item))
+				switch {
+				case c < 0:
+					if !emit(Change[T]{Kind: ChangeRemoved, Old: l.item}) {
+						return
+					}
+					l.advance()
+				case c > 0:
+					if !emit(Change[T]{Kind: ChangeAdded, New: r.item}) {
+						return
+					}
+					r.advance()
+				default:
+					if !equal(l.item, r.item) && !emit(Change[T]{Kind: ChangeModified, Old: l.item, New: r.item}) {
+						return
+					}
+					l.advance()
+					r.advance()
+				}
+			}
+		}
+	}), "DiffSorted", nil, left, right)
+}
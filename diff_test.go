@@ -0,0 +1,60 @@
+package goiterators_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/dreadster3/goiterators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffSorted(t *testing.T) {
+	before := goiterators.NewIteratorFromSlice([]record{{1, "a"}, {2, "b"}, {3, "c"}, {5, "e"}})
+	after := goiterators.NewIteratorFromSlice([]record{{2, "b"}, {3, "C"}, {4, "d"}, {6, "f"}})
+
+	changes := goiterators.DiffSorted(before, after, func(r record) int { return r.Key }, func(a, b record) bool { return a == b })
+	result := slices.Collect(changes.Next)
+
+	expected := []goiterators.Change[record]{
+		{Kind: goiterators.ChangeRemoved, Old: record{1, "a"}},
+		{Kind: goiterators.ChangeModified, Old: record{3, "c"}, New: record{3, "C"}},
+		{Kind: goiterators.ChangeAdded, New: record{4, "d"}},
+		{Kind: goiterators.ChangeRemoved, Old: record{5, "e"}},
+		{Kind: goiterators.ChangeAdded, New: record{6, "f"}},
+	}
+	assert.Equal(t, expected, result)
+	assert.NoError(t, changes.Err())
+}
+
+func TestDiffSortedIdentical(t *testing.T) {
+	data := []record{{1, "a"}, {2, "b"}}
+
+	changes := goiterators.DiffSorted(goiterators.NewIteratorFromSlice(data), goiterators.NewIteratorFromSlice(data),
+		func(r record) int { return r.Key }, func(a, b record) bool { return a == b })
+
+	assert.Empty(t, slices.Collect(changes.Next))
+	assert.NoError(t, changes.Err())
+}
+
+func TestDiffSortedWithError(t *testing.T) {
+	next := func(yield func(record, error) bool) {
+		if !yield(record{1, "a"}, nil) {
+			return
+		}
+		yield(record{}, errors.New("snapshot error"))
+	}
+
+	changes := goiterators.DiffSorted(goiterators.NewIteratorErr(next), goiterators.NewIteratorFromSlice([]record{{1, "a"}, {2, "b"}}),
+		func(r record) int { return r.Key }, func(a, b record) bool { return a == b })
+	result := slices.Collect(changes.Next)
+
+	assert.Empty(t, result)
+	assert.EqualError(t, changes.Err(), "snapshot error")
+}
+
+func TestChangeKindString(t *testing.T) {
+	assert.Equal(t, "added", goiterators.ChangeAdded.String())
+	assert.Equal(t, "removed", goiterators.ChangeRemoved.String())
+	assert.Equal(t, "modified", goiterators.ChangeModified.String())
+}
@@ -0,0 +1,37 @@
+package goiterators
+
+// Distinct returns only the first occurrence of every item
+func Distinct[T comparable](iter Iterator[T]) Iterator[T] {
+	return DistinctBy(iter, func(item T) T {
+		return item
+	})
+}
+
+// DistinctBy returns only the first item for every key, remembering every key seen during the iteration
+func DistinctBy[T any, K comparable](iter Iterator[T], keyFn func(T) K) Iterator[T] {
+	return withSize(withStage(newIterator(func(self *iterator[T], yield func(int, T) bool) {
+		distinct(self, iter, keyFn, NewMapSet[K](), yield)
+	}), "Distinct", nil, iter), SizeHintOf(iter).distinct())
+}
+
+// DistinctWith returns only the items whose key was not yet in the seen set
+// The set is kept across iterations and can be shared, use NewLRUSet or NewBloomSet to bound memory
+func DistinctWith[T any, K comparable](iter Iterator[T], keyFn func(T) K, seen SeenSet[K]) Iterator[T] {
+	return withSize(withStage(newIterator(func(self *iterator[T], yield func(int, T) bool) {
+		distinct(self, iter, keyFn, seen, yield)
+	}), "Distinct", nil, iter), SizeHintOf(iter).upTo())
+}
+
+func distinct[T any, K comparable](self *iterator[T], iter Iterator[T], keyFn func(T) K, seen SeenSet[K], yield func(int, T) bool) {
+	for idx, item := range iter.INext {
+		if seen.Add(keyFn(item)) {
+			if !yield(idx, item) {
+				return
+			}
+		}
+	}
+
+	if iter.Err() != nil {
+		self.err = iter.Err()
+	}
+}
@@ -0,0 +1,86 @@
+package goiterators_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dreadster3/goiterators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistinct(t *testing.T) {
+	data := []int{1, 2, 1, 3, 2, 4, 1}
+	iterator := goiterators.NewIteratorFromSlice(data)
+
+	distinct := goiterators.Distinct(iterator)
+	result := slices.Collect(distinct.Next)
+
+	assert.Equal(t, []int{1, 2, 3, 4}, result)
+	assert.NoError(t, distinct.Err())
+}
+
+func TestDistinctIndices(t *testing.T) {
+	data := []string{"a", "b", "a", "c"}
+	iterator := goiterators.NewIteratorFromSlice(data)
+
+	distinct := goiterators.Distinct(iterator)
+
+	var indices []int
+	for idx := range distinct.INext {
+		indices = append(indices, idx)
+	}
+
+	assert.Equal(t, []int{0, 1, 3}, indices)
+}
+
+func TestDistinctBy(t *testing.T) {
+	data := []string{"apple", "Avocado", "banana", "Blueberry", "cherry"}
+	iterator := goiterators.NewIteratorFromSlice(data)
+
+	distinct := goiterators.DistinctBy(iterator, func(s string) string {
+		return strings.ToLower(s[:1])
+	})
+	result := slices.Collect(distinct.Next)
+
+	assert.Equal(t, []string{"apple", "banana", "cherry"}, result)
+	assert.NoError(t, distinct.Err())
+}
+
+func TestDistinctByReiteration(t *testing.T) {
+	data := []int{1, 1, 2}
+	iterator := goiterators.NewIteratorFromSlice(data)
+
+	distinct := goiterators.Distinct(iterator)
+
+	assert.Equal(t, []int{1, 2}, slices.Collect(distinct.Next))
+	assert.Equal(t, []int{1, 2}, slices.Collect(distinct.Next))
+}
+
+func TestDistinctWithSharedSet(t *testing.T) {
+	seen := goiterators.NewMapSet[int]()
+
+	first := goiterators.DistinctWith(goiterators.NewIteratorFromSlice([]int{1, 2, 3}), func(x int) int { return x }, seen)
+	second := goiterators.DistinctWith(goiterators.NewIteratorFromSlice([]int{3, 4, 1, 5}), func(x int) int { return x }, seen)
+
+	assert.Equal(t, []int{1, 2, 3}, slices.Collect(first.Next))
+	assert.Equal(t, []int{4, 5}, slices.Collect(second.Next))
+}
+
+func TestDistinctWithError(t *testing.T) {
+	next := func(yield func(int, error) bool) {
+		for _, item := range []int{1, 1, 2} {
+			if !yield(item, nil) {
+				return
+			}
+		}
+		yield(0, errors.New("source error"))
+	}
+
+	distinct := goiterators.Distinct(goiterators.NewIteratorErr(next))
+	result := slices.Collect(distinct.Next)
+
+	assert.Equal(t, []int{1, 2}, result)
+	assert.EqualError(t, distinct.Err(), "source error")
+}
@@ -0,0 +1,104 @@
+package goiterators
+
+import (
+	"context"
+	"runtime/pprof"
+	"sync"
+)
+
+// Enriched pairs an item with the value looked up for its key
+type Enriched[T, V any] struct {
+	Item  T
+	Value V
+}
+
+type flightCall[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// flightGroup deduplicates concurrent calls sharing the same key
+type flightGroup[K comparable, V any] struct {
+	mu    sync.Mutex
+	calls map[K]*flightCall[V]
+}
+
+func newFlightGroup[K comparable, V any]() *flightGroup[K, V] {
+	return &flightGroup[K, V]{
+		calls: make(map[K]*flightCall[V]),
+	}
+}
+
+// do runs fn once for all concurrent callers with the same key, every caller gives up waiting when its own context is done
+// fn runs in its own goroutine with the values of the caller starting it but only the cancellation of parent,
+// so a caller whose context is done does not fail the others waiting on the same key
+func (g *flightGroup[K, V]) do(ctx, parent context.Context, key K, fn func(context.Context) (V, error)) (V, error) {
+	g.mu.Lock()
+	call, ok := g.calls[key]
+	if !ok {
+		call = &flightCall[V]{done: make(chan struct{})}
+		g.calls[key] = call
+		go g.run(ctx, parent, key, call, fn)
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return *new(V), ctx.Err()
+	case <-call.done:
+		return call.value, call.err
+	}
+}
+
+// run executes fn for call with a context detached from ctx and cancelled with parent, a panic of fn fails the call with a PanicError
+func (g *flightGroup[K, V]) run(ctx, parent context.Context, key K, call *flightCall[V], fn func(context.Context) (V, error)) {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(parent, cancel)
+	pprof.SetGoroutineLabels(ctx)
+
+	defer func() {
+		if r := recover(); r != nil {
+			call.err = newPanicError(r)
+		}
+
+		stop()
+		cancel()
+
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(call.done)
+	}()
+
+	call.value, call.err = fn(ctx)
+}
+
+// EnrichAsync looks up a value for the key of every item in parallel with context cancellation
+// Concurrent lookups of the same key are deduplicated, and successful lookups are stored in cache when it is not nil
+// A shared lookup is only cancelled with ctx, an item giving up on it through WithItemTimeout or a retry attempt timeout leaves it running for the others
+func EnrichAsync[T any, K comparable, V any](ctx context.Context, iter Iterator[T], keyFn func(T) K, lookup func(context.Context, K) (V, error), cache *LookupCache[K, V], opts ...Option) Iterator[Enriched[T, V]] {
+	group := newFlightGroup[K, V]()
+
+	return withAsyncStage(IMapAsyncCtx(ctx, iter, func(itemCtx context.Context, _ int, item T) (Enriched[T, V], error) {
+		key := keyFn(item)
+		if cache != nil {
+			if value, ok := cache.Get(key); ok {
+				return Enriched[T, V]{Item: item, Value: value}, nil
+			}
+		}
+
+		value, err := group.do(itemCtx, ctx, key, func(ctx context.Context) (V, error) {
+			value, err := lookup(ctx, key)
+			if err == nil && cache != nil {
+				cache.Set(key, value)
+			}
+			return value, err
+		})
+		if err != nil {
+			return Enriched[T, V]{}, err
+		}
+
+		return Enriched[T, V]{Item: item, Value: value}, nil
+	}, opts...), "EnrichAsync", newConfig(opts), iter)
+}
@@ -0,0 +1,147 @@
+package goiterators_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dreadster3/goiterators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnrichAsync(t *testing.T) {
+	data := []event{{1, "login"}, {2, "login"}, {1, "logout"}, {2, "logout"}, {1, "login"}}
+	iterator := goiterators.NewIteratorFromSlice(data)
+
+	var lookups atomic.Int32
+	lookup := func(ctx context.Context, id int) (string, error) {
+		lookups.Add(1)
+		time.Sleep(50 * time.Millisecond)
+		return map[int]string{1: "alice", 2: "bob"}[id], nil
+	}
+
+	enriched := goiterators.EnrichAsync(context.Background(), iterator, func(e event) int { return e.UserID }, lookup, nil)
+
+	var result []string
+	for item := range enriched.Next {
+		result = append(result, item.Value+":"+item.Item.Action)
+	}
+	slices.Sort(result)
+
+	expected := []string{"alice:login", "alice:login", "alice:logout", "bob:login", "bob:logout"}
+	assert.Equal(t, expected, result)
+	assert.NoError(t, enriched.Err())
+	// Concurrent lookups of the same key are deduplicated
+	assert.Equal(t, int32(2), lookups.Load())
+}
+
+func TestEnrichAsyncWithCache(t *testing.T) {
+	cache := goiterators.NewLookupCache[int, string](10, time.Minute)
+
+	var lookups atomic.Int32
+	lookup := func(ctx context.Context, id int) (string, error) {
+		lookups.Add(1)
+		return strings.Repeat("x", id), nil
+	}
+
+	for range 3 {
+		iterator := goiterators.NewIteratorFromSlice([]event{{1, "a"}, {2, "b"}})
+		enriched := goiterators.EnrichAsync(context.Background(), iterator, func(e event) int { return e.UserID }, lookup, cache)
+		assert.Len(t, slices.Collect(enriched.Next), 2)
+		assert.NoError(t, enriched.Err())
+	}
+
+	assert.Equal(t, int32(2), lookups.Load())
+	assert.Equal(t, 2, cache.Len())
+}
+
+func TestEnrichAsyncLookupError(t *testing.T) {
+	iterator := goiterators.NewIteratorFromSlice([]event{{1, "a"}})
+	cache := goiterators.NewLookupCache[int, string](10, 0)
+
+	enriched := goiterators.EnrichAsync(context.Background(), iterator, func(e event) int { return e.UserID },
+		func(ctx context.Context, id int) (string, error) {
+			return "", errors.New("lookup failed")
+		}, cache)
+
+	assert.Empty(t, slices.Collect(enriched.Next))
+	assert.EqualError(t, enriched.Err(), "lookup failed")
+	assert.Equal(t, 0, cache.Len(), "Expected errors not to be cached")
+}
+
+func TestLookupCacheExpiry(t *testing.T) {
+	cache := goiterators.NewLookupCache[string, int](10, 20*time.Millisecond)
+
+	cache.Set("a", 1)
+	value, ok := cache.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+
+	time.Sleep(30 * time.Millisecond)
+
+	_, ok = cache.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestLookupCacheEviction(t *testing.T) {
+	cache := goiterators.NewLookupCache[string, int](2, 0)
+
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+	_, _ = cache.Get("a")
+	cache.Set("c", 3)
+
+	_, ok := cache.Get("b")
+	assert.False(t, ok, "Expected least recently used entry to be evicted")
+	_, ok = cache.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 2, cache.Len())
+}
+
+func TestEnrichAsyncSharedLookupOutlivesCaller(t *testing.T) {
+	iterator := goiterators.NewIteratorFromSlice([]event{{1, "login"}, {1, "logout"}})
+
+	var lookups atomic.Int32
+	lookup := func(ctx context.Context, id int) (string, error) {
+		lookups.Add(1)
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(120 * time.Millisecond):
+			return "alice", nil
+		}
+	}
+
+	// The rate limit starts the second item 50ms after the first, so it joins the lookup of the first item with a later deadline
+	// The first attempt of the first item times out before the lookup ends, which must not fail the lookup for the second item
+	enriched := goiterators.EnrichAsync(context.Background(), iterator, func(e event) int { return e.UserID }, lookup, nil,
+		goiterators.WithRateLimit(20, 1),
+		goiterators.WithRetry(goiterators.RetryPolicy{
+			MaxAttempts:    2,
+			AttemptTimeout: 100 * time.Millisecond,
+		}))
+
+	result := slices.Collect(enriched.Next)
+
+	assert.Len(t, result, 2)
+	assert.NoError(t, enriched.Err())
+	assert.Equal(t, int32(1), lookups.Load())
+}
+
+func TestEnrichAsyncLookupPanic(t *testing.T) {
+	iterator := goiterators.NewIteratorFromSlice([]event{{1, "a"}})
+
+	enriched := goiterators.EnrichAsync(context.Background(), iterator, func(e event) int { return e.UserID },
+		func(ctx context.Context, id int) (string, error) {
+			panic("boom")
+		}, nil)
+
+	assert.Empty(t, slices.Collect(enriched.Next))
+	var panicErr *goiterators.PanicError
+	assert.ErrorAs(t, enriched.Err(), &panicErr)
+}
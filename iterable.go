@@ -0,0 +1,94 @@
+package goiterators
+
+import (
+	"context"
+	"iter"
+)
+
+// Iterable produces a fresh Iterator on every call, so a pipeline defined once can run many times, e.g. per request or per retry
+// The context is passed to the async algorithms of the pipeline
+type Iterable[T any] func(ctx context.Context) Iterator[T]
+
+// IterableFromSlice creates an iterable over the items of a slice
+func IterableFromSlice[T any](slice []T) Iterable[T] {
+	return func(context.Context) Iterator[T] {
+		return NewIteratorFromSlice(slice)
+	}
+}
+
+// Iter runs the pipeline, returning a fresh iterator
+func (it Iterable[T]) Iter(ctx context.Context) Iterator[T] {
+	return it(ctx)
+}
+
+// Collect runs the pipeline and gathers its items, returning the items gathered before an error
+func (it Iterable[T]) Collect(ctx context.Context) ([]T, error) {
+	return Collect(it(ctx))
+}
+
+// Lift turns an algorithm on iterators into an algorithm on iterables
+func Lift[T, U any](fn func(Iterator[T]) Iterator[U]) func(Iterable[T]) Iterable[U] {
+	return func(source Iterable[T]) Iterable[U] {
+		return func(ctx context.Context) Iterator[U] {
+			return fn(source(ctx))
+		}
+	}
+}
+
+// LiftCtx turns an algorithm on iterators taking a context into an algorithm on iterables, it receives the context of every run
+func LiftCtx[T, U any](fn func(context.Context, Iterator[T]) Iterator[U]) func(Iterable[T]) Iterable[U] {
+	return func(source Iterable[T]) Iterable[U] {
+		return func(ctx context.Context) Iterator[U] {
+			return fn(ctx, source(ctx))
+		}
+	}
+}
+
+// MapIterable transforms each item of every run using the provided function
+func MapIterable[T, U any](source Iterable[T], fn func(T) U, opts ...Option) Iterable[U] {
+	return Lift(func(it Iterator[T]) Iterator[U] {
+		return Map(it, fn, opts...)
+	})(source)
+}
+
+// FilterIterable returns only the items of every run that satisfy the predicate function
+func FilterIterable[T any](source Iterable[T], fn func(T) bool, opts ...Option) Iterable[T] {
+	return Lift(func(it Iterator[T]) Iterator[T] {
+		return Filter(it, fn, opts...)
+	})(source)
+}
+
+// FlatMapIterable transforms each item of every run into multiple results using iter.Seq
+func FlatMapIterable[T, U any](source Iterable[T], fn func(T) iter.Seq[U], opts ...Option) Iterable[U] {
+	return Lift(func(it Iterator[T]) Iterator[U] {
+		return FlatMap(it, fn, opts...)
+	})(source)
+}
+
+// TakeIterable returns at most n items of every run
+func TakeIterable[T any](source Iterable[T], n int) Iterable[T] {
+	return Lift(func(it Iterator[T]) Iterator[T] {
+		return Take(it, n)
+	})(source)
+}
+
+// SkipIterable drops the first n items of every run
+func SkipIterable[T any](source Iterable[T], n int) Iterable[T] {
+	return Lift(func(it Iterator[T]) Iterator[T] {
+		return Skip(it, n)
+	})(source)
+}
+
+// MapAsyncIterable transforms each item of every run in parallel with the context of the run
+func MapAsyncIterable[T, U any](source Iterable[T], fn func(context.Context, T) (U, error), opts ...Option) Iterable[U] {
+	return LiftCtx(func(ctx context.Context, it Iterator[T]) Iterator[U] {
+		return MapAsyncCtx(ctx, it, fn, opts...)
+	})(source)
+}
+
+// FilterAsyncIterable returns only the items of every run that satisfy the predicate function, in parallel with the context of the run
+func FilterAsyncIterable[T any](source Iterable[T], fn func(context.Context, T) (bool, error), opts ...Option) Iterable[T] {
+	return LiftCtx(func(ctx context.Context, it Iterator[T]) Iterator[T] {
+		return FilterAsyncCtx(ctx, it, fn, opts...)
+	})(source)
+}
@@ -0,0 +1,127 @@
+package goiterators_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/dreadster3/goiterators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIterableMultiplePasses(t *testing.T) {
+	source := goiterators.IterableFromSlice([]int{1, 2, 3, 4, 5})
+	evens := goiterators.FilterIterable(source, func(x int) bool { return x%2 == 0 })
+	labels := goiterators.MapIterable(evens, strconv.Itoa)
+
+	for range 3 {
+		result, err := labels.Collect(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"2", "4"}, result)
+	}
+}
+
+func TestIterableAsyncRerun(t *testing.T) {
+	channelSource := goiterators.Iterable[int](func(context.Context) goiterators.Iterator[int] {
+		channel := make(chan int)
+		go func() {
+			defer close(channel)
+			for i := range 3 {
+				channel <- i
+			}
+		}()
+		return goiterators.NewAsyncIterator(channel)
+	})
+
+	squared := goiterators.MapAsyncIterable(channelSource, func(ctx context.Context, x int) (int, error) {
+		return x * x, nil
+	}, goiterators.WithOrdered())
+
+	for range 2 {
+		assert.Equal(t, []int{0, 1, 4}, slices.Collect(squared.Iter(context.Background()).Next))
+	}
+}
+
+func TestIterableRunContext(t *testing.T) {
+	source := goiterators.IterableFromSlice([]int{1, 2, 3})
+	fetched := goiterators.MapAsyncIterable(source, func(ctx context.Context, x int) (int, error) {
+		return x, ctx.Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := fetched.Collect(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	result, err := fetched.Collect(context.Background())
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []int{1, 2, 3}, result)
+}
+
+func TestIterableRetryAfterError(t *testing.T) {
+	attempts := 0
+	source := goiterators.Iterable[int](func(context.Context) goiterators.Iterator[int] {
+		attempts++
+		failing := attempts == 1
+		return goiterators.NewIteratorErr(func(yield func(int, error) bool) {
+			if !yield(1, nil) {
+				return
+			}
+			if failing {
+				yield(0, errors.New("transient"))
+				return
+			}
+			yield(2, nil)
+		})
+	})
+	doubled := goiterators.MapIterable(source, func(x int) int { return x * 2 })
+
+	_, err := doubled.Collect(context.Background())
+	assert.EqualError(t, err, "transient")
+
+	result, err := doubled.Collect(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 4}, result)
+}
+
+func TestLift(t *testing.T) {
+	reverse := goiterators.Lift(goiterators.Reverse[int])
+	windowed := goiterators.TakeIterable(goiterators.SkipIterable(goiterators.IterableFromSlice([]int{1, 2, 3, 4, 5}), 1), 3)
+
+	result, err := reverse(windowed).Collect(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{4, 3, 2}, result)
+}
+
+func TestLiftCtx(t *testing.T) {
+	type key struct{}
+	tagged := goiterators.LiftCtx(func(ctx context.Context, it goiterators.Iterator[int]) goiterators.Iterator[string] {
+		return goiterators.Map(it, func(x int) string {
+			return ctx.Value(key{}).(string) + strconv.Itoa(x)
+		})
+	})(goiterators.IterableFromSlice([]int{1, 2}))
+
+	first, _ := tagged.Collect(context.WithValue(context.Background(), key{}, "a"))
+	second, _ := tagged.Collect(context.WithValue(context.Background(), key{}, "b"))
+
+	assert.Equal(t, []string{"a1", "a2"}, first)
+	assert.Equal(t, []string{"b1", "b2"}, second)
+}
+
+func TestFlatMapAndFilterAsyncIterable(t *testing.T) {
+	source := goiterators.IterableFromSlice([]int{1, 2, 3})
+	repeated := goiterators.FlatMapIterable(source, func(x int) iter.Seq[int] {
+		return slices.Values(slices.Repeat([]int{x}, x))
+	})
+	odd := goiterators.FilterAsyncIterable(repeated, func(ctx context.Context, x int) (bool, error) {
+		return x%2 == 1, nil
+	}, goiterators.WithOrdered())
+
+	result, err := odd.Collect(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 3, 3, 3}, result)
+}
@@ -1,9 +1,6 @@
 package goiterators
 
-import (
-	"iter"
-	"slices"
-)
+import "iter"
 
 // Iterator provides sequential access to items with optional error handling
 type Iterator[T any] interface {
@@ -15,8 +12,11 @@ type Iterator[T any] interface {
 type nextFunc[T any] func(self *iterator[T], yield func(int, T) bool)
 
 type iterator[T any] struct {
-	next nextFunc[T]
-	err  error
+	next  nextFunc[T]
+	err   error
+	info  *stageInfo
+	size  SizeHint
+	slice *sliceSource[T]
 }
 
 // newIterator creates an iterator with error checking wrapper
@@ -34,6 +34,7 @@ func newIterator[T any](next nextFunc[T]) *iterator[T] {
 // NewIterator creates an iterator from a standard Go iter.Seq2[int, T]
 func NewIterator[T any](next iter.Seq2[int, T]) Iterator[T] {
 	return &iterator[T]{
+		info: &stageInfo{kind: "Seq"},
 		next: func(self *iterator[T], yield func(int, T) bool) {
 			for i, item := range next {
 				if !yield(i, item) {
@@ -47,6 +48,7 @@ func NewIterator[T any](next iter.Seq2[int, T]) Iterator[T] {
 // NewIteratorErr creates an iterator that handles errors from iter.Seq2[T, error]
 func NewIteratorErr[T any](next iter.Seq2[T, error]) Iterator[T] {
 	return &iterator[T]{
+		info: &stageInfo{kind: "Seq"},
 		next: func(self *iterator[T], yield func(int, T) bool) {
 			if self.Err() != nil {
 				return
@@ -69,7 +71,7 @@ func NewIteratorErr[T any](next iter.Seq2[T, error]) Iterator[T] {
 
 // NewIteratorFromSlice creates an iterator from a slice
 func NewIteratorFromSlice[T any](slice []T) Iterator[T] {
-	return NewIterator(slices.All(slice))
+	return withStage(newSliceIterator(&sliceSource[T]{items: slice}), "Slice", nil)
 }
 
 func (it *iterator[T]) Err() error {
@@ -0,0 +1,187 @@
+package goiterators
+
+import "cmp"
+
+// JoinKind selects which unmatched items a join keeps
+type JoinKind int
+
+const (
+	// InnerJoin keeps only the items matched on both sides
+	InnerJoin JoinKind = iota
+	// LeftJoin also keeps the unmatched left items
+	LeftJoin
+	// RightJoin also keeps the unmatched right items
+	RightJoin
+	// FullJoin keeps the unmatched items of both sides
+	FullJoin
+)
+
+func (k JoinKind) String() string {
+	switch k {
+	case InnerJoin:
+		return "inner"
+	case LeftJoin:
+		return "left"
+	case RightJoin:
+		return "right"
+	case FullJoin:
+		return "full"
+	default:
+		return "unknown"
+	}
+}
+
+func (k JoinKind) keepsLeft() bool {
+	return k == LeftJoin || k == FullJoin
+}
+
+func (k JoinKind) keepsRight() bool {
+	return k == RightJoin || k == FullJoin
+}
+
+// Joined is a pair of joined items, HasLeft or HasRight is false when the item is missing from that side in an outer join
+type Joined[L, R any] struct {
+	Left     L
+	Right    R
+	HasLeft  bool
+	HasRight bool
+}
+
+// HashJoin joins the items sharing the same key, materialising right in memory and streaming left
+// Matches are yielded in left order, and unmatched right items of right and full joins are yielded last in right order
+func HashJoin[L, R any, K comparable](left Iterator[L], right Iterator[R], leftKey func(L) K, rightKey func(R) K, kind JoinKind) Iterator[Joined[L, R]] {
+	return withStage(newIterator(func(self *iterator[Joined[L, R]], yield func(int, Joined[L, R]) bool) {
+		var build []R
+		index := make(map[K][]int)
+		for item := range right.Next {
+			key := rightKey(item)
+			index[key] = append(index[key], len(build))
+			build = append(build, item)
+		}
+
+		if right.Err() != nil {
+			self.err = right.Err()
+			return
+		}
+
+		idx := 0
+		emit := func(joined Joined[L, R]) bool {
+			if !yield(idx, joined) {
+				return false
+			}
+			idx++
+			return true
+		}
+
+		matched := make([]bool, len(build))
+		for item := range left.Next {
+			positions := index[leftKey(item)]
+			if len(positions) == 0 && kind.keepsLeft() {
+				if !emit(Joined[L, R]{Left: item, HasLeft: true}) {
+					return
+				}
+			}
+
+			for _, pos := range positions {
+				matched[pos] = true
+				if !emit(Joined[L, R]{Left: item, Right: build[pos], HasLeft: true, HasRight: true}) {
+					return
+				}
+			}
+		}
+
+		if left.Err() != nil {
+			self.err = left.Err()
+			return
+		}
+
+		if kind.keepsRight() {
+			for pos, item := range build {
+				if !matched[pos] {
+					if !emit(Joined[L, R]{Right: item, HasRight: true}) {
+						return
+					}
+				}
+			}
+		}
+	}), "HashJoin", nil, left, right)
+}
+
+// MergeJoin joins the items sharing the same key from two iterators both sorted by key, buffering only the right items of the current key
+func MergeJoin[L, R any, K cmp.Ordered](left Iterator[L], right Iterator[R], leftKey func(L) K, rightKey func(R) K, kind JoinKind) Iterator[Joined[L, R]] {
+	return withStage(newIterator(func(self *iterator[Joined[L, R]], yield func(int, Joined[L, R]) bool) {
+		l := newCursor(left)
+		defer l.stop()
+		r := newCursor(right)
+		defer r.stop()
+
+		idx := 0
+		emit := func(joined Joined[L, R]) bool {
+			if !yield(idx, joined) {
+				return false
+			}
+			idx++
+			return true
+		}
+
+		for {
+			if err := l.err(); err != nil {
+				self.err = err
+				return
+			}
+			if err := r.err(); err != nil {
+				self.err = err
+				return
+			}
+
+			switch {
+			case !l.ok && !r.ok:
+				return
+			case !r.ok:
+				if !kind.keepsLeft() || !emit(Joined[L, R]{Left: l.item, HasLeft: true}) {
+					return
+				}
+				l.advance()
+			case !l.ok:
+				if !kind.keepsRight() || !emit(Joined[L, R]{Right: r.item, HasRight: true}) {
+					return
+				}
+				r.advance()
+			default:
+				key := leftKey(l.item)
+				c := cmp.Compare(key, rightKey(r.item))
+				switch {
+				case c < 0:
+					if kind.keepsLeft() && !emit(Joined[L, R]{Left: l.item, HasLeft: true}) {
+						return
+					}
+					l.advance()
+				case c > 0:
+					if kind.keepsRight() && !emit(Joined[L, R]{Right: r.item, HasRight: true}) {
+						return
+					}
+					r.advance()
+				default:
+					var group []R
+					for r.ok && cmp.Compare(rightKey(r.item), key) == 0 {
+						group = append(group, r.item)
+						r.advance()
+					}
+					if err := r.err(); err != nil {
+						self.err = err
+						return
+					}
+
+					for l.ok && cmp.Compare(leftKey(l.item), key) == 0 {
+						for _, item := range group {
+							if !emit(Joined[L, R]{Left: l.item, Right: item, HasLeft: true, HasRight: true}) {
+								return
+							}
+						}
+						l.advance()
+					}
+				}
+			}
+		}
+	}), "MergeJoin", nil, left, right)
+}
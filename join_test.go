@@ -0,0 +1,126 @@
+package goiterators_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/dreadster3/goiterators"
+	"github.com/stretchr/testify/assert"
+)
+
+type event struct {
+	UserID int
+	Action string
+}
+
+type user struct {
+	ID   int
+	Name string
+}
+
+type joinFunc func(goiterators.Iterator[event], goiterators.Iterator[user], goiterators.JoinKind) goiterators.Iterator[goiterators.Joined[event, user]]
+
+var joins = map[string]joinFunc{
+	"hash": func(left goiterators.Iterator[event], right goiterators.Iterator[user], kind goiterators.JoinKind) goiterators.Iterator[goiterators.Joined[event, user]] {
+		return goiterators.HashJoin(left, right, func(e event) int { return e.UserID }, func(u user) int { return u.ID }, kind)
+	},
+	"merge": func(left goiterators.Iterator[event], right goiterators.Iterator[user], kind goiterators.JoinKind) goiterators.Iterator[goiterators.Joined[event, user]] {
+		return goiterators.MergeJoin(left, right, func(e event) int { return e.UserID }, func(u user) int { return u.ID }, kind)
+	},
+}
+
+// Both sides are sorted by key so the same input works for hash and merge joins
+var (
+	joinEvents = []event{{1, "login"}, {1, "logout"}, {2, "login"}, {4, "login"}}
+	joinUsers  = []user{{1, "alice"}, {2, "bob"}, {3, "carol"}}
+)
+
+func TestJoins(t *testing.T) {
+	matched := []goiterators.Joined[event, user]{
+		{Left: event{1, "login"}, Right: user{1, "alice"}, HasLeft: true, HasRight: true},
+		{Left: event{1, "logout"}, Right: user{1, "alice"}, HasLeft: true, HasRight: true},
+		{Left: event{2, "login"}, Right: user{2, "bob"}, HasLeft: true, HasRight: true},
+	}
+	unmatchedLeft := goiterators.Joined[event, user]{Left: event{4, "login"}, HasLeft: true}
+	unmatchedRight := goiterators.Joined[event, user]{Right: user{3, "carol"}, HasRight: true}
+
+	expected := map[goiterators.JoinKind][]goiterators.Joined[event, user]{
+		goiterators.InnerJoin: matched,
+		goiterators.LeftJoin:  append(slices.Clone(matched), unmatchedLeft),
+		goiterators.RightJoin: append(slices.Clone(matched), unmatchedRight),
+		goiterators.FullJoin:  append(slices.Clone(matched), unmatchedLeft, unmatchedRight),
+	}
+
+	for name, join := range joins {
+		for kind, want := range expected {
+			t.Run(name+"/"+kind.String(), func(t *testing.T) {
+				joined := join(goiterators.NewIteratorFromSlice(joinEvents), goiterators.NewIteratorFromSlice(joinUsers), kind)
+				result := slices.Collect(joined.Next)
+
+				assert.ElementsMatch(t, want, result)
+				assert.NoError(t, joined.Err())
+			})
+		}
+	}
+}
+
+func TestHashJoinOrder(t *testing.T) {
+	joined := goiterators.HashJoin(
+		goiterators.NewIteratorFromSlice([]event{{2, "a"}, {9, "b"}, {1, "c"}}),
+		goiterators.NewIteratorFromSlice(joinUsers),
+		func(e event) int { return e.UserID }, func(u user) int { return u.ID },
+		goiterators.FullJoin,
+	)
+
+	var actions []string
+	var names []string
+	for item := range joined.Next {
+		actions = append(actions, item.Left.Action)
+		names = append(names, item.Right.Name)
+	}
+
+	assert.Equal(t, []string{"a", "b", "c", ""}, actions)
+	assert.Equal(t, []string{"bob", "", "alice", "carol"}, names)
+}
+
+func TestMergeJoinManyToMany(t *testing.T) {
+	left := goiterators.NewIteratorFromSlice([]event{{1, "a"}, {1, "b"}})
+	right := goiterators.NewIteratorFromSlice([]user{{1, "x"}, {1, "y"}})
+
+	joined := goiterators.MergeJoin(left, right, func(e event) int { return e.UserID }, func(u user) int { return u.ID }, goiterators.InnerJoin)
+
+	var pairs []string
+	for item := range joined.Next {
+		pairs = append(pairs, item.Left.Action+item.Right.Name)
+	}
+
+	assert.Equal(t, []string{"ax", "ay", "bx", "by"}, pairs)
+}
+
+func TestJoinsWithError(t *testing.T) {
+	failing := func() goiterators.Iterator[user] {
+		return goiterators.NewIteratorErr(func(yield func(user, error) bool) {
+			if !yield(user{1, "alice"}, nil) {
+				return
+			}
+			yield(user{}, errors.New("reference error"))
+		})
+	}
+
+	for name, join := range joins {
+		t.Run(name, func(t *testing.T) {
+			joined := join(goiterators.NewIteratorFromSlice(joinEvents), failing(), goiterators.InnerJoin)
+			_ = slices.Collect(joined.Next)
+
+			assert.EqualError(t, joined.Err(), "reference error")
+		})
+	}
+}
+
+func TestJoinKindString(t *testing.T) {
+	assert.Equal(t, "inner", goiterators.InnerJoin.String())
+	assert.Equal(t, "left", goiterators.LeftJoin.String())
+	assert.Equal(t, "right", goiterators.RightJoin.String())
+	assert.Equal(t, "full", goiterators.FullJoin.String())
+}
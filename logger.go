@@ -0,0 +1,141 @@
+package goiterators
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+// LogLevels sets the level of each event logged by WithLogger
+type LogLevels struct {
+	// StageStart is the level of the message logged when an async stage starts
+	StageStart slog.Level
+	// StageFinish is the level of the message logged when an async stage finishes
+	StageFinish slog.Level
+	// ItemError is the level of the message logged when the stage function fails for an item
+	ItemError slog.Level
+	// Cancel is the level of the message logged when the context of the stage is cancelled
+	Cancel slog.Level
+	// Panic is the level of the message logged when the stage function or the source iterator panics
+	Panic slog.Level
+}
+
+// DefaultLogLevels are the levels used by WithLogger unless overridden with WithLogLevels
+var DefaultLogLevels = LogLevels{
+	StageStart:  slog.LevelDebug,
+	StageFinish: slog.LevelDebug,
+	ItemError:   slog.LevelError,
+	Cancel:      slog.LevelWarn,
+	Panic:       slog.LevelError,
+}
+
+// WithName names the stage, the name is attached to log messages as the stage attribute and reported by Describe
+func WithName(name string) Option {
+	return func(c *config) {
+		c.name = name
+	}
+}
+
+// WithLogger logs the events of the stage to logger: the start and finish of async stages, item errors with their index,
+// context cancellations and panics, at the levels of DefaultLogLevels unless overridden with WithLogLevels
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) {
+		c.logger = logger
+	}
+}
+
+// WithLogLevels overrides the levels of the events logged by WithLogger
+func WithLogLevels(levels LogLevels) Option {
+	return func(c *config) {
+		c.logLevels = &levels
+	}
+}
+
+// log writes a message with the stage attribute when a logger is configured
+func (c *config) log(level slog.Level, msg string, attrs ...slog.Attr) {
+	if c.logger == nil {
+		return
+	}
+
+	if c.name != "" {
+		attrs = append([]slog.Attr{slog.String("stage", c.name)}, attrs...)
+	}
+
+	c.logger.LogAttrs(context.Background(), level, msg, attrs...)
+}
+
+func (c *config) levels() LogLevels {
+	if c.logLevels != nil {
+		return *c.logLevels
+	}
+
+	return DefaultLogLevels
+}
+
+// logStageStart logs the start of an async stage and returns a function logging its finish
+func (c *config) logStageStart() func(items int) {
+	if c.logger == nil {
+		return func(int) {}
+	}
+
+	c.log(c.levels().StageStart, "stage started")
+
+	start := time.Now()
+	return func(items int) {
+		c.log(c.levels().StageFinish, "stage finished", slog.Int("items", items), slog.Duration("elapsed", time.Since(start)))
+	}
+}
+
+// logItemError logs the failure of the stage function for the item at idx
+func (c *config) logItemError(ctx context.Context, idx int, err error) {
+	c.logFailure(ctx, "item", slog.Int("index", idx), err)
+}
+
+// logBatchError logs the failure of a batch function for all the items at indices
+func (c *config) logBatchError(ctx context.Context, indices []int, err error) {
+	c.logFailure(ctx, "batch", slog.Any("indices", indices), err)
+}
+
+// logFailure logs err as a failure of subject, identified by attr
+func (c *config) logFailure(ctx context.Context, subject string, attr slog.Attr, err error) {
+	if c.logger == nil || err == nil {
+		return
+	}
+
+	var panicErr *PanicError
+	switch {
+	case errors.As(err, &panicErr):
+		c.log(c.levels().Panic, subject+" panicked", attr, slog.Any("error", err), slog.String("stack", string(panicErr.Stack)))
+	case ctx.Err() != nil && errors.Is(err, ctx.Err()):
+		c.log(c.levels().Cancel, subject+" cancelled", attr, slog.Any("error", err))
+	default:
+		c.log(c.levels().ItemError, subject+" failed", attr, slog.Any("error", err))
+	}
+}
+
+// logStageError logs an error stopping an async stage, such as a cancellation or a failure of the source iterator
+func (c *config) logStageError(err error) {
+	if c.logger == nil {
+		return
+	}
+
+	var panicErr *PanicError
+	switch {
+	case errors.As(err, &panicErr):
+		c.log(c.levels().Panic, "source panicked", slog.Any("error", err), slog.String("stack", string(panicErr.Stack)))
+	case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+		c.log(c.levels().Cancel, "stage cancelled", slog.Any("error", err))
+	default:
+		c.log(c.levels().ItemError, "source failed", slog.Any("error", err))
+	}
+}
+
+// logCall returns a callFunc logging the failure of fn for the item at idx
+func (c *config) logCall(idx int, fn callFunc) callFunc {
+	return func(ctx context.Context) error {
+		err := fn(ctx)
+		c.logItemError(ctx, idx, err)
+		return err
+	}
+}
@@ -0,0 +1,188 @@
+package goiterators_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dreadster3/goiterators"
+	"github.com/stretchr/testify/assert"
+)
+
+// logBuffer is a buffer safe to read while async stages are still logging
+type logBuffer struct {
+	mu     sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *logBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buffer.Write(p)
+}
+
+func (b *logBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buffer.String()
+}
+
+func newTestLogger(buffer *logBuffer) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(buffer, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
+
+func logEntries(t *testing.T, buffer *logBuffer) []map[string]any {
+	var entries []map[string]any
+	for line := range strings.SplitSeq(strings.TrimSpace(buffer.String()), "\n") {
+		if line == "" {
+			continue
+		}
+
+		var entry map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(line), &entry))
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+func findEntry(entries []map[string]any, msg string) map[string]any {
+	for _, entry := range entries {
+		if entry["msg"] == msg {
+			return entry
+		}
+	}
+
+	return nil
+}
+
+func TestMapAsyncCtxWithLogger(t *testing.T) {
+	var buffer logBuffer
+	iterator := goiterators.NewIteratorFromSlice([]int{1, 2, 3})
+
+	mapped := goiterators.MapAsyncCtx(context.Background(), iterator, func(ctx context.Context, x int) (int, error) {
+		return x, nil
+	}, goiterators.WithName("fetch"), goiterators.WithLogger(newTestLogger(&buffer)))
+
+	_ = slices.Collect(mapped.Next)
+	assert.NoError(t, mapped.Err())
+
+	entries := logEntries(t, &buffer)
+
+	started := findEntry(entries, "stage started")
+	assert.NotNil(t, started)
+	assert.Equal(t, "DEBUG", started["level"])
+	assert.Equal(t, "fetch", started["stage"])
+
+	finished := findEntry(entries, "stage finished")
+	assert.NotNil(t, finished)
+	assert.Equal(t, "fetch", finished["stage"])
+	assert.Equal(t, float64(3), finished["items"])
+}
+
+func TestMapAsyncCtxWithLoggerItemError(t *testing.T) {
+	var buffer logBuffer
+	iterator := goiterators.NewIteratorFromSlice([]int{1, 2, 3})
+
+	mapped := goiterators.MapAsyncCtx(context.Background(), iterator, func(ctx context.Context, x int) (int, error) {
+		if x == 2 {
+			return 0, errors.New("bad item")
+		}
+		return x, nil
+	}, goiterators.WithName("fetch"), goiterators.WithLogger(newTestLogger(&buffer)))
+
+	_ = slices.Collect(mapped.Next)
+	assert.EqualError(t, mapped.Err(), "bad item")
+
+	failed := findEntry(logEntries(t, &buffer), "item failed")
+	assert.NotNil(t, failed)
+	assert.Equal(t, "ERROR", failed["level"])
+	assert.Equal(t, "fetch", failed["stage"])
+	assert.Equal(t, float64(1), failed["index"])
+	assert.Equal(t, "bad item", failed["error"])
+}
+
+func TestMapAsyncWithLoggerPanic(t *testing.T) {
+	var buffer logBuffer
+	iterator := goiterators.NewIteratorFromSlice([]int{1})
+
+	mapped := goiterators.MapAsync(iterator, func(x int) int {
+		panic("boom")
+	}, goiterators.WithLogger(newTestLogger(&buffer)))
+
+	_ = slices.Collect(mapped.Next)
+
+	panicked := findEntry(logEntries(t, &buffer), "item panicked")
+	assert.NotNil(t, panicked)
+	assert.Equal(t, "ERROR", panicked["level"])
+	assert.Equal(t, float64(0), panicked["index"])
+	assert.Equal(t, "panic: boom", panicked["error"])
+	assert.NotEmpty(t, panicked["stack"])
+	assert.NotContains(t, panicked, "stage")
+}
+
+func TestForEachAsyncCtxWithLoggerCancel(t *testing.T) {
+	var buffer logBuffer
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	iterator := goiterators.NewIteratorFromSlice([]int{1, 2, 3})
+	err := goiterators.ForEachAsyncCtx(ctx, iterator, func(ctx context.Context, x int) error {
+		return nil
+	}, goiterators.WithLogger(newTestLogger(&buffer)))
+
+	assert.ErrorIs(t, err, context.Canceled)
+
+	cancelled := findEntry(logEntries(t, &buffer), "stage cancelled")
+	assert.NotNil(t, cancelled)
+	assert.Equal(t, "WARN", cancelled["level"])
+	assert.Equal(t, "context canceled", cancelled["error"])
+}
+
+func TestMapAsyncWithLogLevels(t *testing.T) {
+	var buffer logBuffer
+	logger := slog.New(slog.NewJSONHandler(&buffer, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	iterator := goiterators.NewIteratorFromSlice([]int{1})
+
+	mapped := goiterators.MapAsyncCtx(context.Background(), iterator, func(ctx context.Context, x int) (int, error) {
+		return 0, errors.New("expected")
+	}, goiterators.WithLogger(logger), goiterators.WithLogLevels(goiterators.LogLevels{
+		StageStart:  slog.LevelInfo,
+		StageFinish: slog.LevelDebug,
+		ItemError:   slog.LevelWarn,
+		Cancel:      slog.LevelWarn,
+		Panic:       slog.LevelError,
+	}))
+
+	_ = slices.Collect(mapped.Next)
+
+	entries := logEntries(t, &buffer)
+	assert.Equal(t, "INFO", findEntry(entries, "stage started")["level"])
+	assert.Equal(t, "WARN", findEntry(entries, "item failed")["level"])
+	assert.Nil(t, findEntry(entries, "stage finished"), "Expected debug messages to be filtered out")
+}
+
+func TestFilterWithLogger(t *testing.T) {
+	var buffer logBuffer
+	iterator := goiterators.NewIteratorFromSlice([]int{1, 2, 3})
+
+	filtered := goiterators.Filter(iterator, func(x int) bool {
+		if x == 3 {
+			panic("boom")
+		}
+		return true
+	}, goiterators.WithName("validate"), goiterators.WithLogger(newTestLogger(&buffer)))
+
+	assert.Equal(t, []int{1, 2}, slices.Collect(filtered.Next))
+
+	panicked := findEntry(logEntries(t, &buffer), "item panicked")
+	assert.NotNil(t, panicked)
+	assert.Equal(t, "validate", panicked["stage"])
+	assert.Equal(t, float64(2), panicked["index"])
+}
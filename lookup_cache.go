@@ -0,0 +1,65 @@
+package goiterators
+
+import (
+	"sync"
+	"time"
+)
+
+type lookupCacheEntry[V any] struct {
+	value   V
+	expires time.Time
+}
+
+// LookupCache is a bounded least recently used cache whose entries expire after a time to live, safe for concurrent use
+type LookupCache[K comparable, V any] struct {
+	mu      sync.Mutex
+	entries *lru[K, lookupCacheEntry[V]]
+	ttl     time.Duration
+}
+
+// NewLookupCache creates a cache holding at most capacity entries for ttl each, a non-positive ttl never expires entries
+func NewLookupCache[K comparable, V any](capacity int, ttl time.Duration) *LookupCache[K, V] {
+	return &LookupCache[K, V]{
+		entries: newLRU[K, lookupCacheEntry[V]](capacity),
+		ttl:     ttl,
+	}
+}
+
+// Get returns the cached value for key if present and not expired
+func (c *LookupCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries.get(key)
+	if !ok {
+		return *new(V), false
+	}
+
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		c.entries.remove(key)
+		return *new(V), false
+	}
+
+	return entry.value, true
+}
+
+// Set caches value for key, evicting the least recently used entry when full
+func (c *LookupCache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := lookupCacheEntry[V]{value: value}
+	if c.ttl > 0 {
+		entry.expires = time.Now().Add(c.ttl)
+	}
+
+	c.entries.put(key, entry)
+}
+
+// Len returns the number of cached entries, including expired entries not yet evicted
+func (c *LookupCache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.entries.len()
+}
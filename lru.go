@@ -0,0 +1,65 @@
+package goiterators
+
+import "container/list"
+
+// lru is a map bounded to a capacity that evicts the least recently used entries
+type lru[K comparable, V any] struct {
+	capacity int
+	items    map[K]*list.Element
+	order    *list.List
+}
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// newLRU creates an lru holding at most capacity entries
+func newLRU[K comparable, V any](capacity int) *lru[K, V] {
+	return &lru[K, V]{
+		capacity: max(capacity, 1),
+		items:    make(map[K]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// get returns the value for key and marks it as recently used
+func (l *lru[K, V]) get(key K) (V, bool) {
+	element, ok := l.items[key]
+	if !ok {
+		return *new(V), false
+	}
+
+	l.order.MoveToFront(element)
+	return element.Value.(*lruEntry[K, V]).value, true
+}
+
+// put stores the value for key, evicting the least recently used entry when full
+func (l *lru[K, V]) put(key K, value V) {
+	if element, ok := l.items[key]; ok {
+		element.Value.(*lruEntry[K, V]).value = value
+		l.order.MoveToFront(element)
+		return
+	}
+
+	if l.order.Len() >= l.capacity {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.items, oldest.Value.(*lruEntry[K, V]).key)
+	}
+
+	l.items[key] = l.order.PushFront(&lruEntry[K, V]{key: key, value: value})
+}
+
+// remove deletes the entry for key
+func (l *lru[K, V]) remove(key K) {
+	if element, ok := l.items[key]; ok {
+		l.order.Remove(element)
+		delete(l.items, key)
+	}
+}
+
+// len returns the number of entries
+func (l *lru[K, V]) len() int {
+	return l.order.Len()
+}
@@ -0,0 +1,117 @@
+package goiterators
+
+import (
+	"container/heap"
+	"iter"
+)
+
+// pullFunc returns the next item of a sorted source, reporting false once it is exhausted
+type pullFunc[T any] func() (T, bool, error)
+
+type mergeEntry[T any] struct {
+	item   T
+	source int
+}
+
+type mergeHeap[T any] struct {
+	entries []mergeEntry[T]
+	less    func(a, b T) bool
+}
+
+func (h *mergeHeap[T]) Len() int { return len(h.entries) }
+
+func (h *mergeHeap[T]) Less(i, j int) bool {
+	a, b := h.entries[i], h.entries[j]
+	if h.less(a.item, b.item) {
+		return true
+	}
+	if h.less(b.item, a.item) {
+		return false
+	}
+
+	// Equal items keep the order of their sources
+	return a.source < b.source
+}
+
+func (h *mergeHeap[T]) Swap(i, j int) { h.entries[i], h.entries[j] = h.entries[j], h.entries[i] }
+
+func (h *mergeHeap[T]) Push(x any) { h.entries = append(h.entries, x.(mergeEntry[T])) }
+
+func (h *mergeHeap[T]) Pop() any {
+	last := h.entries[len(h.entries)-1]
+	h.entries = h.entries[:len(h.entries)-1]
+	return last
+}
+
+// kWayMerge lazily merges sorted sources into yield, returning the first error raised by a source
+func kWayMerge[T any](less func(a, b T) bool, sources []pullFunc[T], yield func(T) bool) error {
+	h := &mergeHeap[T]{
+		entries: make([]mergeEntry[T], 0, len(sources)),
+		less:    less,
+	}
+
+	for i, pull := range sources {
+		item, ok, err := pull()
+		if err != nil {
+			return err
+		}
+		if ok {
+			h.entries = append(h.entries, mergeEntry[T]{item: item, source: i})
+		}
+	}
+	heap.Init(h)
+
+	for h.Len() > 0 {
+		entry := h.entries[0]
+		if !yield(entry.item) {
+			return nil
+		}
+
+		item, ok, err := sources[entry.source]()
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			h.entries[0].item = item
+			heap.Fix(h, 0)
+		} else {
+			heap.Pop(h)
+		}
+	}
+
+	return nil
+}
+
+// MergeSorted lazily merges iterators already sorted according to less into a single sorted iterator
+// Equal items are yielded in the order of the iterators they come from, and the first error raised by an iterator stops the merge
+func MergeSorted[T any](less func(a, b T) bool, its ...Iterator[T]) Iterator[T] {
+	return withStage(newIterator(func(self *iterator[T], yield func(int, T) bool) {
+		sources := make([]pullFunc[T], len(its))
+		for i, it := range its {
+			next, stop := iter.Pull(it.Next)
+			defer stop()
+
+			sources[i] = func() (T, bool, error) {
+				item, ok := next()
+				if !ok {
+					return *new(T), false, it.Err()
+				}
+
+				return item, true, nil
+			}
+		}
+
+		idx := 0
+		err := kWayMerge(less, sources, func(item T) bool {
+			if !yield(idx, item) {
+				return false
+			}
+			idx++
+			return true
+		})
+		if err != nil {
+			self.err = err
+		}
+	}), "MergeSorted", nil, inputsOf(its)...)
+}
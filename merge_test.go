@@ -0,0 +1,80 @@
+package goiterators_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/dreadster3/goiterators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeSorted(t *testing.T) {
+	first := goiterators.NewIteratorFromSlice([]int{1, 4, 7, 10})
+	second := goiterators.NewIteratorFromSlice([]int{2, 5, 8})
+	third := goiterators.NewIteratorFromSlice([]int{3, 6, 9, 11, 12})
+
+	merged := goiterators.MergeSorted(func(a, b int) bool { return a < b }, first, second, third)
+
+	var indices []int
+	var result []int
+	for idx, item := range merged.INext {
+		indices = append(indices, idx)
+		result = append(result, item)
+	}
+
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, result)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}, indices)
+	assert.NoError(t, merged.Err())
+}
+
+func TestMergeSortedStable(t *testing.T) {
+	first := goiterators.NewIteratorFromSlice([]record{{1, "first"}, {2, "first"}})
+	second := goiterators.NewIteratorFromSlice([]record{{1, "second"}, {2, "second"}})
+
+	merged := goiterators.MergeSorted(func(a, b record) bool { return a.Key < b.Key }, first, second)
+	result := slices.Collect(merged.Next)
+
+	expected := []record{{1, "first"}, {1, "second"}, {2, "first"}, {2, "second"}}
+	assert.Equal(t, expected, result)
+}
+
+func TestMergeSortedEmpty(t *testing.T) {
+	merged := goiterators.MergeSorted(func(a, b int) bool { return a < b })
+	assert.Empty(t, slices.Collect(merged.Next))
+	assert.NoError(t, merged.Err())
+
+	merged = goiterators.MergeSorted(func(a, b int) bool { return a < b },
+		goiterators.NewIteratorFromSlice([]int{}),
+		goiterators.NewIteratorFromSlice([]int{1}),
+	)
+	assert.Equal(t, []int{1}, slices.Collect(merged.Next))
+}
+
+func TestMergeSortedWithError(t *testing.T) {
+	next := func(yield func(int, error) bool) {
+		if !yield(3, nil) {
+			return
+		}
+		yield(0, errors.New("shard error"))
+	}
+
+	merged := goiterators.MergeSorted(func(a, b int) bool { return a < b },
+		goiterators.NewIteratorFromSlice([]int{1, 2, 4, 5}),
+		goiterators.NewIteratorErr(next),
+	)
+	result := slices.Collect(merged.Next)
+
+	assert.Equal(t, []int{1, 2, 3}, result)
+	assert.EqualError(t, merged.Err(), "shard error")
+}
+
+func TestMergeSortedEarlyStop(t *testing.T) {
+	merged := goiterators.MergeSorted(func(a, b int) bool { return a < b },
+		goiterators.NewIteratorFromSlice([]int{1, 3, 5}),
+		goiterators.NewIteratorFromSlice([]int{2, 4, 6}),
+	)
+
+	result := slices.Collect(goiterators.Take(merged, 3).Next)
+	assert.Equal(t, []int{1, 2, 3}, result)
+}
@@ -0,0 +1,187 @@
+package goiterators
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultLatencyBuckets are the upper bounds of the latency histogram buckets used by NewMetricsCollector
+var DefaultLatencyBuckets = []time.Duration{
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// MetricsCollector aggregates the events of named stages in memory, safe for concurrent use
+type MetricsCollector struct {
+	mu      sync.Mutex
+	buckets []time.Duration
+	stages  map[string]*StageMetrics
+}
+
+// NewMetricsCollector creates a collector using the given latency bucket upper bounds, or DefaultLatencyBuckets when none are given
+func NewMetricsCollector(buckets ...time.Duration) *MetricsCollector {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+
+	buckets = slices.Clone(buckets)
+	slices.Sort(buckets)
+
+	return &MetricsCollector{
+		buckets: slices.Compact(buckets),
+		stages:  make(map[string]*StageMetrics),
+	}
+}
+
+// Stage returns the metrics of the stage with the given name, registering it on first use
+// The returned StageMetrics is an Observer to attach to the stage with WithObserver
+func (c *MetricsCollector) Stage(name string) *StageMetrics {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stage, ok := c.stages[name]
+	if !ok {
+		stage = &StageMetrics{
+			name:    name,
+			buckets: c.buckets,
+			counts:  make([]atomic.Uint64, len(c.buckets)),
+		}
+		c.stages[name] = stage
+	}
+
+	return stage
+}
+
+// Snapshot returns the statistics of every registered stage ordered by name
+func (c *MetricsCollector) Snapshot() []StageStats {
+	c.mu.Lock()
+	stages := make([]*StageMetrics, 0, len(c.stages))
+	for _, stage := range c.stages {
+		stages = append(stages, stage)
+	}
+	c.mu.Unlock()
+
+	slices.SortFunc(stages, func(a, b *StageMetrics) int {
+		return cmp.Compare(a.name, b.name)
+	})
+
+	stats := make([]StageStats, len(stages))
+	for i, stage := range stages {
+		stats[i] = stage.Stats()
+	}
+
+	return stats
+}
+
+// StageMetrics counts the events of a single stage
+type StageMetrics struct {
+	name string
+
+	itemsIn        atomic.Uint64
+	itemsOut       atomic.Uint64
+	errors         atomic.Uint64
+	workersStarted atomic.Uint64
+	inFlight       atomic.Int64
+
+	buckets      []time.Duration
+	counts       []atomic.Uint64
+	latencyCount atomic.Uint64
+	latencySum   atomic.Int64
+}
+
+// StageStats is a snapshot of the statistics of a stage
+type StageStats struct {
+	Name           string
+	ItemsIn        uint64
+	ItemsOut       uint64
+	Errors         uint64
+	WorkersStarted uint64
+	InFlight       int64
+	Latency        HistogramSnapshot
+}
+
+// HistogramSnapshot is a snapshot of a latency histogram with cumulative bucket counts
+type HistogramSnapshot struct {
+	Buckets []HistogramBucket
+	Count   uint64
+	Sum     time.Duration
+}
+
+// HistogramBucket counts the observations less than or equal to UpperBound
+type HistogramBucket struct {
+	UpperBound time.Duration
+	Count      uint64
+}
+
+func (s *StageMetrics) OnItemIn(int) {
+	s.itemsIn.Add(1)
+}
+
+func (s *StageMetrics) OnItemOut(_ int, elapsed time.Duration) {
+	s.itemsOut.Add(1)
+
+	// Observations above the last bound only count towards the total
+	if i, _ := slices.BinarySearch(s.buckets, elapsed); i < len(s.buckets) {
+		s.counts[i].Add(1)
+	}
+	s.latencyCount.Add(1)
+	s.latencySum.Add(int64(elapsed))
+}
+
+func (s *StageMetrics) OnError(int, error) {
+	s.errors.Add(1)
+}
+
+func (s *StageMetrics) OnWorkerStart(int) {
+	s.workersStarted.Add(1)
+	s.inFlight.Add(1)
+}
+
+func (s *StageMetrics) OnWorkerStop(int, time.Duration) {
+	s.inFlight.Add(-1)
+}
+
+// Name returns the name of the stage
+func (s *StageMetrics) Name() string {
+	return s.name
+}
+
+// Stats returns a snapshot of the statistics of the stage
+func (s *StageMetrics) Stats() StageStats {
+	histogram := HistogramSnapshot{
+		Buckets: make([]HistogramBucket, len(s.buckets)),
+	}
+
+	// OnItemOut increments a bucket before the total, so an observation in progress can be counted in a bucket only
+	// The total is raised to the cumulative count so that it is never below a bucket
+	var cumulative uint64
+	for i, bound := range s.buckets {
+		cumulative += s.counts[i].Load()
+		histogram.Buckets[i] = HistogramBucket{UpperBound: bound, Count: cumulative}
+	}
+	histogram.Count = max(s.latencyCount.Load(), cumulative)
+	histogram.Sum = time.Duration(s.latencySum.Load())
+
+	return StageStats{
+		Name:           s.name,
+		ItemsIn:        s.itemsIn.Load(),
+		ItemsOut:       s.itemsOut.Load(),
+		Errors:         s.errors.Load(),
+		WorkersStarted: s.workersStarted.Load(),
+		InFlight:       s.inFlight.Load(),
+		Latency:        histogram,
+	}
+}
@@ -0,0 +1,122 @@
+package goiterators_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dreadster3/goiterators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsCollector(t *testing.T) {
+	collector := goiterators.NewMetricsCollector(10*time.Millisecond, 50*time.Millisecond)
+	iterator := goiterators.NewIteratorFromSlice([]int{1, 2, 3, 4})
+
+	mapped := goiterators.MapAsyncCtx(context.Background(), iterator, func(ctx context.Context, x int) (int, error) {
+		switch x {
+		case 3:
+			time.Sleep(20 * time.Millisecond)
+		case 4:
+			// Fail last so every other worker has delivered its result
+			time.Sleep(40 * time.Millisecond)
+			return 0, errors.New("failed")
+		}
+		return x, nil
+	}, goiterators.WithObserver(collector.Stage("fetch")))
+	_ = slices.Collect(mapped.Next)
+
+	filtered := goiterators.Filter(goiterators.NewIteratorFromSlice([]int{1, 2}), func(x int) bool {
+		return x > 1
+	}, goiterators.WithObserver(collector.Stage("filter")))
+	_ = slices.Collect(filtered.Next)
+
+	time.Sleep(20 * time.Millisecond)
+	stats := collector.Snapshot()
+	assert.Len(t, stats, 2)
+
+	// Stages are ordered by name
+	assert.Equal(t, "fetch", stats[0].Name)
+	assert.Equal(t, uint64(4), stats[0].ItemsIn)
+	assert.Equal(t, uint64(3), stats[0].ItemsOut)
+	assert.Equal(t, uint64(1), stats[0].Errors)
+	assert.Equal(t, uint64(4), stats[0].WorkersStarted)
+	assert.Equal(t, int64(0), stats[0].InFlight)
+
+	latency := stats[0].Latency
+	assert.Equal(t, uint64(3), latency.Count)
+	assert.GreaterOrEqual(t, latency.Sum, 20*time.Millisecond)
+	assert.Equal(t, []goiterators.HistogramBucket{
+		{UpperBound: 10 * time.Millisecond, Count: 2},
+		{UpperBound: 50 * time.Millisecond, Count: 3},
+	}, latency.Buckets)
+
+	assert.Equal(t, "filter", stats[1].Name)
+	assert.Equal(t, uint64(2), stats[1].ItemsOut)
+	assert.Equal(t, uint64(0), stats[1].WorkersStarted)
+}
+
+func TestMetricsCollectorInFlight(t *testing.T) {
+	collector := goiterators.NewMetricsCollector()
+	stage := collector.Stage("slow")
+	assert.Same(t, stage, collector.Stage("slow"))
+	assert.Equal(t, "slow", stage.Name())
+
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_ = goiterators.ForEachAsync(goiterators.NewIteratorFromSlice([]int{1, 2, 3}), func(x int) error {
+			<-release
+			return nil
+		}, goiterators.WithObserver(stage))
+	}()
+
+	assert.Eventually(t, func() bool {
+		return stage.Stats().InFlight == 3
+	}, time.Second, 5*time.Millisecond)
+
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int64(0), stage.Stats().InFlight)
+	assert.Len(t, stage.Stats().Latency.Buckets, len(goiterators.DefaultLatencyBuckets))
+}
+
+func TestMetricsCollectorConcurrentSnapshot(t *testing.T) {
+	stage := goiterators.NewMetricsCollector(time.Millisecond).Stage("concurrent")
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 10000 {
+				stage.OnItemOut(i, 0)
+			}
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	for {
+		latency := stage.Stats().Latency
+		// The total must never be below a bucket, Prometheus rejects such histograms
+		assert.GreaterOrEqual(t, latency.Count, latency.Buckets[0].Count)
+
+		select {
+		case <-done:
+			assert.Equal(t, uint64(80000), stage.Stats().Latency.Count)
+			return
+		default:
+		}
+	}
+}
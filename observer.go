@@ -0,0 +1,90 @@
+package goiterators
+
+import (
+	"context"
+	"time"
+)
+
+// Observer receives events about the items flowing through a stage, implementations must be safe for concurrent use
+type Observer interface {
+	// OnItemIn is called when the stage receives an item
+	OnItemIn(index int)
+	// OnItemOut is called when the stage function successfully processed an item, with the time it took
+	OnItemOut(index int, elapsed time.Duration)
+	// OnError is called when the stage function failed to process an item
+	OnError(index int, err error)
+	// OnWorkerStart is called when an async stage starts a worker for an item
+	OnWorkerStart(index int)
+	// OnWorkerStop is called when an async worker finishes, with the time it was running
+	OnWorkerStop(index int, elapsed time.Duration)
+}
+
+// NopObserver ignores every event, embed it to implement only some Observer methods
+type NopObserver struct{}
+
+func (NopObserver) OnItemIn(int)                    {}
+func (NopObserver) OnItemOut(int, time.Duration)    {}
+func (NopObserver) OnError(int, error)              {}
+func (NopObserver) OnWorkerStart(int)               {}
+func (NopObserver) OnWorkerStop(int, time.Duration) {}
+
+// WithObserver reports the events of the stage to observer, it can be passed several times to attach several observers
+func WithObserver(observer Observer) Option {
+	return func(c *config) {
+		if observer != nil {
+			c.observers = append(c.observers, observer)
+		}
+	}
+}
+
+func (c *config) itemIn(idx int) {
+	for _, observer := range c.observers {
+		observer.OnItemIn(idx)
+	}
+}
+
+func (c *config) itemOut(idx int, elapsed time.Duration) {
+	for _, observer := range c.observers {
+		observer.OnItemOut(idx, elapsed)
+	}
+}
+
+func (c *config) itemError(idx int, err error) {
+	for _, observer := range c.observers {
+		observer.OnError(idx, err)
+	}
+}
+
+// workerStart reports the start of the worker for the item at idx and returns a function reporting its stop
+func (c *config) workerStart(idx int) func() {
+	if len(c.observers) == 0 {
+		return func() {}
+	}
+
+	for _, observer := range c.observers {
+		observer.OnWorkerStart(idx)
+	}
+
+	start := time.Now()
+	return func() {
+		elapsed := time.Since(start)
+		for _, observer := range c.observers {
+			observer.OnWorkerStop(idx, elapsed)
+		}
+	}
+}
+
+// observe returns a callFunc reporting the outcome of fn for the item at idx
+func (c *config) observe(idx int, fn callFunc) callFunc {
+	return func(ctx context.Context) error {
+		start := time.Now()
+		err := fn(ctx)
+		if err != nil {
+			c.itemError(idx, err)
+		} else {
+			c.itemOut(idx, time.Since(start))
+		}
+
+		return err
+	}
+}
@@ -0,0 +1,142 @@
+package goiterators_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dreadster3/goiterators"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingObserver struct {
+	mu             sync.Mutex
+	in             []int
+	out            []int
+	errs           []int
+	workersStarted []int
+	workersStopped []int
+}
+
+func (o *recordingObserver) OnItemIn(index int) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.in = append(o.in, index)
+}
+
+func (o *recordingObserver) OnItemOut(index int, _ time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.out = append(o.out, index)
+}
+
+func (o *recordingObserver) OnError(index int, _ error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.errs = append(o.errs, index)
+}
+
+func (o *recordingObserver) OnWorkerStart(index int) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.workersStarted = append(o.workersStarted, index)
+}
+
+func (o *recordingObserver) OnWorkerStop(index int, _ time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.workersStopped = append(o.workersStopped, index)
+}
+
+func TestMapWithObserver(t *testing.T) {
+	observer := &recordingObserver{}
+	iterator := goiterators.NewIteratorFromSlice([]int{1, 2, 3})
+
+	mapped := goiterators.Map(iterator, func(x int) int {
+		return x * 2
+	}, goiterators.WithObserver(observer))
+
+	assert.Equal(t, []int{2, 4, 6}, slices.Collect(mapped.Next))
+	assert.Equal(t, []int{0, 1, 2}, observer.in)
+	assert.Equal(t, []int{0, 1, 2}, observer.out)
+	assert.Empty(t, observer.errs)
+	assert.Empty(t, observer.workersStarted)
+}
+
+func TestFilterAndFlatMapWithObserver(t *testing.T) {
+	filterObserver := &recordingObserver{}
+	flatMapObserver := &recordingObserver{}
+	iterator := goiterators.NewIteratorFromSlice([]int{1, 2, 3, 4})
+
+	filtered := goiterators.Filter(iterator, func(x int) bool {
+		return x%2 == 0
+	}, goiterators.WithObserver(filterObserver))
+	flattened := goiterators.FlatMap(filtered, func(x int) iter.Seq[int] {
+		return slices.Values([]int{x, x})
+	}, goiterators.WithObserver(flatMapObserver))
+
+	assert.Equal(t, []int{2, 2, 4, 4}, slices.Collect(flattened.Next))
+	assert.Equal(t, []int{0, 1, 2, 3}, filterObserver.out)
+	assert.Equal(t, []int{1, 3}, flatMapObserver.in)
+}
+
+func TestForEachWithObserverError(t *testing.T) {
+	observer := &recordingObserver{}
+	iterator := goiterators.NewIteratorFromSlice([]int{1, 2, 3})
+
+	err := goiterators.ForEach(iterator, func(x int) error {
+		if x == 2 {
+			return errors.New("failed")
+		}
+		return nil
+	}, goiterators.WithObserver(observer))
+
+	assert.Error(t, err)
+	assert.Equal(t, []int{0}, observer.out)
+	assert.Equal(t, []int{1}, observer.errs)
+}
+
+func TestIMapAsyncCtxWithObserver(t *testing.T) {
+	observer := &recordingObserver{}
+	iterator := goiterators.NewIteratorFromSlice([]int{1, 2, 3, 4})
+
+	mapped := goiterators.IMapAsyncCtx(context.Background(), iterator, func(ctx context.Context, idx int, x int) (int, error) {
+		if idx == 3 {
+			// Fail last so every other worker has delivered its result
+			time.Sleep(20 * time.Millisecond)
+			return 0, errors.New("failed")
+		}
+		return x, nil
+	}, goiterators.WithObserver(observer))
+
+	_ = slices.Collect(mapped.Next)
+	// Wait for every worker to report its stop
+	time.Sleep(20 * time.Millisecond)
+
+	observer.mu.Lock()
+	defer observer.mu.Unlock()
+
+	assert.ElementsMatch(t, []int{0, 1, 2, 3}, observer.in)
+	assert.ElementsMatch(t, []int{0, 1, 2}, observer.out)
+	assert.Equal(t, []int{3}, observer.errs)
+	assert.ElementsMatch(t, []int{0, 1, 2, 3}, observer.workersStarted)
+	assert.ElementsMatch(t, []int{0, 1, 2, 3}, observer.workersStopped)
+}
+
+func TestWithMultipleObservers(t *testing.T) {
+	first := &recordingObserver{}
+	second := &recordingObserver{}
+	iterator := goiterators.NewIteratorFromSlice([]int{1, 2})
+
+	err := goiterators.ForEachAsync(iterator, func(x int) error {
+		return nil
+	}, goiterators.WithObserver(first), goiterators.WithObserver(second), goiterators.WithObserver(goiterators.NopObserver{}))
+
+	assert.NoError(t, err)
+	assert.Len(t, first.out, 2)
+	assert.Len(t, second.out, 2)
+}
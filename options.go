@@ -0,0 +1,121 @@
+package goiterators
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Option configures the behaviour of an algorithm
+// Options that do not apply to an algorithm are ignored
+type Option func(*config)
+
+type config struct {
+	concurrency int
+	ordered     bool
+
+	limiter *tokenBucket
+	retry   *RetryPolicy
+	breaker *CircuitBreaker
+
+	itemTimeout time.Duration
+	repanic     bool
+
+	observers []Observer
+
+	name      string
+	logger    *slog.Logger
+	logLevels *LogLevels
+
+	pipelineID string
+
+	total int
+}
+
+// newConfig builds a config from the provided options
+func newConfig(opts []Option) *config {
+	cfg := &config{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(cfg)
+		}
+	}
+
+	return cfg
+}
+
+// WithConcurrency bounds the number of workers running at once, a non-positive limit spawns a worker per item
+func WithConcurrency(limit int) Option {
+	return func(c *config) {
+		c.concurrency = limit
+	}
+}
+
+// WithOrdered yields the results of async algorithms in the order of the input items instead of completion order
+// Items are still processed in parallel, but a slow item holds back the results of the items after it
+func WithOrdered() Option {
+	return func(c *config) {
+		c.ordered = true
+	}
+}
+
+// callFunc is a single invocation of an item function
+type callFunc func(context.Context) error
+
+// call runs fn for the item at idx through the configured middleware
+func (c *config) call(ctx context.Context, idx int, fn callFunc) error {
+	fn = recoverCall(fn)
+	if c.breaker != nil {
+		fn = c.breaker.wrap(fn)
+	}
+	if c.retry != nil {
+		fn = c.retry.wrap(idx, c.limiter, fn)
+	}
+	if c.itemTimeout > 0 {
+		fn = withItemTimeout(idx, c.itemTimeout, fn)
+	}
+	if len(c.observers) > 0 {
+		fn = c.observe(idx, fn)
+	}
+	if c.logger != nil {
+		fn = c.logCall(idx, fn)
+	}
+
+	return fn(ctx)
+}
+
+// invoke runs fn for the item at idx through the configured middleware and returns its result
+func invoke[R any](ctx context.Context, cfg *config, idx int, fn func(context.Context) (R, error)) (R, error) {
+	var result R
+	err := cfg.call(ctx, idx, func(ctx context.Context) error {
+		var err error
+		result, err = fn(ctx)
+		return err
+	})
+	if err != nil {
+		return *new(R), err
+	}
+
+	return result, nil
+}
+
+// callSync runs fn for the item at idx in the consumer goroutine, reporting it to the observers and the logger and recovering from panics
+func callSync[R any](cfg *config, idx int, fn func() (R, error)) (R, error) {
+	if len(cfg.observers) == 0 {
+		result, err := protect(cfg, fn)
+		cfg.logItemError(context.Background(), idx, err)
+		return result, err
+	}
+
+	cfg.itemIn(idx)
+	start := time.Now()
+	result, err := protect(cfg, fn)
+	if err != nil {
+		cfg.itemError(idx, err)
+		cfg.logItemError(context.Background(), idx, err)
+	} else {
+		cfg.itemOut(idx, time.Since(start))
+	}
+
+	return result, err
+}
@@ -0,0 +1,90 @@
+package goiterators_test
+
+import (
+	"context"
+	"iter"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dreadster3/goiterators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapAsyncWithConcurrency(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	iterator := goiterators.NewIteratorFromSlice(data)
+
+	var running, maxRunning atomic.Int32
+	mapped := goiterators.MapAsync(iterator, func(x int) int {
+		current := running.Add(1)
+		for {
+			peak := maxRunning.Load()
+			if current <= peak || maxRunning.CompareAndSwap(peak, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		running.Add(-1)
+		return x
+	}, goiterators.WithConcurrency(2))
+
+	result := slices.Collect(mapped.Next)
+	slices.Sort(result)
+
+	assert.Equal(t, data, result)
+	assert.NoError(t, mapped.Err())
+	assert.Equal(t, int32(2), maxRunning.Load())
+}
+
+func TestMapAsyncWithOrdered(t *testing.T) {
+	data := []int{5, 4, 3, 2, 1}
+	iterator := goiterators.NewIteratorFromSlice(data)
+
+	start := time.Now()
+	mapped := goiterators.MapAsync(iterator, func(x int) int {
+		time.Sleep(time.Duration(x) * 10 * time.Millisecond)
+		return x * 2
+	}, goiterators.WithOrdered())
+
+	var indices []int
+	var result []int
+	for idx, item := range mapped.INext {
+		indices = append(indices, idx)
+		result = append(result, item)
+	}
+	elapsed := time.Since(start)
+
+	assert.Equal(t, []int{10, 8, 6, 4, 2}, result)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, indices)
+	assert.NoError(t, mapped.Err())
+	assert.Less(t, elapsed, 150*time.Millisecond, "Expected items to still be processed in parallel")
+}
+
+func TestFlatMapAsyncCtxWithOrderedAndConcurrency(t *testing.T) {
+	data := []int{1, 2, 3, 4}
+	iterator := goiterators.NewIteratorFromSlice(data)
+
+	flattened := goiterators.FlatMapAsyncCtx(context.Background(), iterator, func(ctx context.Context, x int) (iter.Seq[int], error) {
+		time.Sleep(time.Duration(5-x) * 5 * time.Millisecond)
+		return slices.Values([]int{x, x * 10}), nil
+	}, goiterators.WithOrdered(), goiterators.WithConcurrency(2))
+
+	assert.Equal(t, []int{1, 10, 2, 20, 3, 30, 4, 40}, slices.Collect(flattened.Next))
+	assert.NoError(t, flattened.Err())
+}
+
+func TestMapAsyncCtxWithOrderedCancellation(t *testing.T) {
+	iterator := goiterators.NewIteratorFromSlice([]int{1, 2, 3})
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+
+	mapped := goiterators.MapAsyncCtx(ctx, iterator, func(ctx context.Context, x int) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}, goiterators.WithOrdered(), goiterators.WithConcurrency(1))
+
+	assert.Empty(t, slices.Collect(mapped.Next))
+	assert.ErrorIs(t, mapped.Err(), context.DeadlineExceeded)
+}
@@ -0,0 +1,65 @@
+package goiterators
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is returned when an item function panics
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func newPanicError(value any) *PanicError {
+	return &PanicError{
+		Value: value,
+		Stack: debug.Stack(),
+	}
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the panic value when it is an error
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+
+	return nil
+}
+
+// WithRepanic propagates panics from item functions to the goroutine consuming the iterator instead of reporting them through Err()
+// Async algorithms re-panic with the PanicError so the original stack trace is preserved
+func WithRepanic() Option {
+	return func(c *config) {
+		c.repanic = true
+	}
+}
+
+// recoverPanic converts a panic into a PanicError stored in err, it must be deferred directly
+func recoverPanic(err *error) {
+	if r := recover(); r != nil {
+		*err = newPanicError(r)
+	}
+}
+
+// recoverCall returns a callFunc converting panics in fn into a PanicError
+func recoverCall(fn callFunc) callFunc {
+	return func(ctx context.Context) (err error) {
+		defer recoverPanic(&err)
+		return fn(ctx)
+	}
+}
+
+// protect calls fn in the consumer goroutine, converting a panic into a PanicError unless the config asks to re-panic
+func protect[R any](cfg *config, fn func() (R, error)) (result R, err error) {
+	if !cfg.repanic {
+		defer recoverPanic(&err)
+	}
+
+	return fn()
+}
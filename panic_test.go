@@ -0,0 +1,189 @@
+package goiterators_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dreadster3/goiterators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapAsyncPanicRecovery(t *testing.T) {
+	data := []int{1, 2, 3}
+	iterator := goiterators.NewIteratorFromSlice(data)
+
+	mapped := goiterators.MapAsync(iterator, func(x int) int {
+		if x == 2 {
+			panic("boom")
+		}
+		return x
+	})
+
+	_ = slices.Collect(mapped.Next)
+
+	var panicErr *goiterators.PanicError
+	assert.ErrorAs(t, mapped.Err(), &panicErr)
+	assert.Equal(t, "boom", panicErr.Value)
+	assert.Contains(t, string(panicErr.Stack), "panic_test.go")
+	assert.Equal(t, "panic: boom", panicErr.Error())
+}
+
+func TestForEachAsyncCtxPanicRecoveryWithError(t *testing.T) {
+	errCause := errors.New("cause")
+	iterator := goiterators.NewIteratorFromSlice([]int{1})
+
+	err := goiterators.ForEachAsyncCtx(context.Background(), iterator, func(ctx context.Context, x int) error {
+		panic(errCause)
+	})
+
+	var panicErr *goiterators.PanicError
+	assert.ErrorAs(t, err, &panicErr)
+	assert.ErrorIs(t, err, errCause)
+}
+
+func TestFlatMapAsyncPanicRecoveryInSeq(t *testing.T) {
+	iterator := goiterators.NewIteratorFromSlice([]int{1})
+
+	flattened := goiterators.FlatMapAsync(iterator, func(x int) iter.Seq[int] {
+		return func(yield func(int) bool) {
+			panic("seq panic")
+		}
+	})
+
+	_ = slices.Collect(flattened.Next)
+
+	var panicErr *goiterators.PanicError
+	assert.ErrorAs(t, flattened.Err(), &panicErr)
+	assert.Equal(t, "seq panic", panicErr.Value)
+}
+
+func TestMapAsyncCtxPanicRecoveryWithItemTimeout(t *testing.T) {
+	iterator := goiterators.NewIteratorFromSlice([]int{1})
+
+	mapped := goiterators.MapAsyncCtx(context.Background(), iterator, func(ctx context.Context, x int) (int, error) {
+		panic("boom")
+	}, goiterators.WithItemTimeout(time.Second), goiterators.WithRetry(goiterators.RetryPolicy{MaxAttempts: 3}))
+
+	_ = slices.Collect(mapped.Next)
+
+	var panicErr *goiterators.PanicError
+	assert.ErrorAs(t, mapped.Err(), &panicErr)
+
+	// Panics are not retried by default
+	var retryErr *goiterators.RetryError
+	assert.ErrorAs(t, mapped.Err(), &retryErr)
+	assert.Equal(t, 1, retryErr.Attempts)
+}
+
+func TestMapAsyncPanicInSourceIterator(t *testing.T) {
+	source := goiterators.NewIterator(func(yield func(int, int) bool) {
+		if !yield(0, 1) {
+			return
+		}
+		panic("source panic")
+	})
+
+	mapped := goiterators.MapAsync(source, func(x int) int {
+		return x
+	})
+
+	_ = slices.Collect(mapped.Next)
+
+	var panicErr *goiterators.PanicError
+	assert.ErrorAs(t, mapped.Err(), &panicErr)
+	assert.Equal(t, "source panic", panicErr.Value)
+}
+
+func TestMapAsyncWithRepanic(t *testing.T) {
+	iterator := goiterators.NewIteratorFromSlice([]int{1})
+
+	mapped := goiterators.MapAsync(iterator, func(x int) int {
+		panic("boom")
+	}, goiterators.WithRepanic())
+
+	defer func() {
+		r := recover()
+		panicErr, ok := r.(*goiterators.PanicError)
+		assert.True(t, ok)
+		assert.Equal(t, "boom", panicErr.Value)
+	}()
+
+	_ = slices.Collect(mapped.Next)
+	assert.Fail(t, "Expected panic in consumer goroutine")
+}
+
+func TestMapPanicRecovery(t *testing.T) {
+	data := []int{1, 2, 3}
+	iterator := goiterators.NewIteratorFromSlice(data)
+
+	mapped := goiterators.Map(iterator, func(x int) int {
+		if x == 3 {
+			panic("boom")
+		}
+		return x * 2
+	})
+
+	result := slices.Collect(mapped.Next)
+
+	assert.Equal(t, []int{2, 4}, result)
+	var panicErr *goiterators.PanicError
+	assert.ErrorAs(t, mapped.Err(), &panicErr)
+}
+
+func TestFilterWithRepanic(t *testing.T) {
+	iterator := goiterators.NewIteratorFromSlice([]int{1})
+
+	filtered := goiterators.Filter(iterator, func(x int) bool {
+		panic("boom")
+	}, goiterators.WithRepanic())
+
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = slices.Collect(filtered.Next)
+	})
+}
+
+func TestForEachPanicRecovery(t *testing.T) {
+	iterator := goiterators.NewIteratorFromSlice([]int{1, 2})
+
+	err := goiterators.ForEach(iterator, func(x int) error {
+		panic("boom")
+	})
+
+	var panicErr *goiterators.PanicError
+	assert.ErrorAs(t, err, &panicErr)
+}
+
+func TestMapDoesNotRecoverConsumerPanic(t *testing.T) {
+	iterator := goiterators.NewIteratorFromSlice([]int{1})
+	mapped := goiterators.Map(iterator, func(x int) int {
+		return x
+	})
+
+	assert.PanicsWithValue(t, "consumer", func() {
+		for range mapped.Next {
+			panic("consumer")
+		}
+	})
+}
+
+func TestMapAsyncWithRepanicAndRetry(t *testing.T) {
+	iterator := goiterators.NewIteratorFromSlice([]int{1})
+
+	mapped := goiterators.MapAsync(iterator, func(x int) int {
+		panic("boom")
+	}, goiterators.WithRepanic(), goiterators.WithRetry(goiterators.RetryPolicy{MaxAttempts: 2}))
+
+	defer func() {
+		r := recover()
+		panicErr, ok := r.(*goiterators.PanicError)
+		assert.True(t, ok)
+		assert.Equal(t, "boom", panicErr.Value)
+	}()
+
+	_ = slices.Collect(mapped.Next)
+	assert.Fail(t, "Expected panic in consumer goroutine")
+}
@@ -0,0 +1,36 @@
+package goiterators
+
+import (
+	"context"
+	"runtime/pprof"
+)
+
+// pprof label keys attached to the goroutines started by async algorithms
+const (
+	// LabelAlgorithm holds the algorithm of the stage, such as MapAsync or ForEachAsync
+	LabelAlgorithm = "goiterators.algorithm"
+	// LabelStage holds the name given with WithName
+	LabelStage = "goiterators.stage"
+	// LabelPipeline holds the identifier given with WithPipelineID
+	LabelPipeline = "goiterators.pipeline"
+)
+
+// WithPipelineID tags the goroutines of the stage with a pipeline identifier, pass the same identifier to every stage of a pipeline
+func WithPipelineID(id string) Option {
+	return func(c *config) {
+		c.pipelineID = id
+	}
+}
+
+// labelled returns ctx carrying the pprof labels of a stage running algorithm
+func (c *config) labelled(ctx context.Context, algorithm string) context.Context {
+	labels := []string{LabelAlgorithm, algorithm}
+	if c.name != "" {
+		labels = append(labels, LabelStage, c.name)
+	}
+	if c.pipelineID != "" {
+		labels = append(labels, LabelPipeline, c.pipelineID)
+	}
+
+	return pprof.WithLabels(ctx, pprof.Labels(labels...))
+}
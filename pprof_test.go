@@ -0,0 +1,76 @@
+package goiterators_test
+
+import (
+	"bytes"
+	"context"
+	"runtime/pprof"
+	"slices"
+	"testing"
+
+	"github.com/dreadster3/goiterators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapAsyncCtxPprofLabels(t *testing.T) {
+	iterator := goiterators.NewIteratorFromSlice([]int{1})
+
+	mapped := goiterators.MapAsyncCtx(context.Background(), iterator, func(ctx context.Context, x int) (map[string]string, error) {
+		labels := make(map[string]string)
+		pprof.ForLabels(ctx, func(key, value string) bool {
+			labels[key] = value
+			return true
+		})
+		return labels, nil
+	}, goiterators.WithName("fetch"), goiterators.WithPipelineID("import-42"))
+
+	result := slices.Collect(mapped.Next)
+
+	assert.Equal(t, []map[string]string{{
+		goiterators.LabelAlgorithm: "MapAsync",
+		goiterators.LabelStage:     "fetch",
+		goiterators.LabelPipeline:  "import-42",
+	}}, result)
+}
+
+func TestForEachAsyncPprofGoroutineLabels(t *testing.T) {
+	iterator := goiterators.NewIteratorFromSlice([]int{1, 2})
+
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- goiterators.ForEachAsync(iterator, func(x int) error {
+			started <- struct{}{}
+			<-release
+			return nil
+		}, goiterators.WithName("stuck"))
+	}()
+
+	<-started
+	<-started
+
+	var dump bytes.Buffer
+	assert.NoError(t, pprof.Lookup("goroutine").WriteTo(&dump, 1))
+	close(release)
+	assert.NoError(t, <-done)
+
+	assert.Contains(t, dump.String(), `"goiterators.algorithm":"ForEachAsync"`)
+	assert.Contains(t, dump.String(), `"goiterators.stage":"stuck"`)
+}
+
+func TestNewAsyncIteratorPprofLabels(t *testing.T) {
+	channel := make(chan int)
+	iterator := goiterators.NewAsyncIterator(channel)
+
+	go func() {
+		channel <- 1
+	}()
+
+	var dump bytes.Buffer
+	for range iterator.Next {
+		assert.NoError(t, pprof.Lookup("goroutine").WriteTo(&dump, 1))
+		close(channel)
+	}
+
+	assert.Contains(t, dump.String(), `"goiterators.algorithm":"NewAsyncIterator"`)
+}
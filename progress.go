@@ -0,0 +1,116 @@
+package goiterators
+
+import (
+	"sync"
+	"time"
+)
+
+// ProgressInfo reports how far an iteration went
+type ProgressInfo struct {
+	// Count is the number of items yielded so far
+	Count int
+	// Total is the expected number of items, zero when unknown
+	Total int
+	// Elapsed is the time since the first item was requested
+	Elapsed time.Duration
+	// Rate is the average number of items per second
+	Rate float64
+	// ETA is the estimated time left until Total items are yielded, zero when unknown
+	ETA time.Duration
+	// Done is set on the final report, once the iteration ended
+	Done bool
+}
+
+// Percent returns the completed percentage of Total, or zero when Total is unknown
+func (p ProgressInfo) Percent() float64 {
+	if p.Total <= 0 {
+		return 0
+	}
+
+	return float64(p.Count) / float64(p.Total) * 100
+}
+
+// WithTotal sets the expected number of items reported by Progress, overriding the length known by the source
+func WithTotal(total int) Option {
+	return func(c *config) {
+		c.total = total
+	}
+}
+
+// Progress counts the items flowing through and calls fn with the progress every interval while the iteration runs, even when no item arrives,
+// and once more when the iteration ends, even on error or early stop
+// Periodic reports come from another goroutine but fn is never called concurrently, a non-positive interval reports every item instead
+// The expected total is taken from WithTotal, or from the exact size hint of iter like the length of NewIteratorFromSlice
+func Progress[T any](iter Iterator[T], interval time.Duration, fn func(ProgressInfo), opts ...Option) Iterator[T] {
+	cfg := newConfig(opts)
+
+	total := cfg.total
+	if exact, ok := SizeHintOf(iter).Exact(); total <= 0 && ok {
+		total = exact
+	}
+
+	return withSize(withStage(newIterator(func(self *iterator[T], yield func(int, T) bool) {
+		start := time.Now()
+
+		// The count is shared with the ticker goroutine, which also serializes the calls to fn
+		var mu sync.Mutex
+		count := 0
+
+		report := func(done bool) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			info := ProgressInfo{Count: count, Total: total, Elapsed: time.Since(start), Done: done}
+			if seconds := info.Elapsed.Seconds(); seconds > 0 {
+				info.Rate = float64(count) / seconds
+			}
+			if total > count && info.Rate > 0 {
+				info.ETA = time.Duration(float64(total-count) / info.Rate * float64(time.Second))
+			}
+
+			fn(info)
+		}
+		defer report(true)
+
+		// Report on a ticker so a stalled source still reports its elapsed time and ETA, it is stopped before the final report
+		if interval > 0 {
+			ticker := time.NewTicker(interval)
+			stop := make(chan struct{})
+			stopped := make(chan struct{})
+			go func() {
+				defer close(stopped)
+				for {
+					select {
+					case <-stop:
+						return
+					case <-ticker.C:
+						report(false)
+					}
+				}
+			}()
+			defer func() {
+				ticker.Stop()
+				close(stop)
+				<-stopped
+			}()
+		}
+
+		for idx, item := range iter.INext {
+			mu.Lock()
+			count++
+			mu.Unlock()
+
+			if interval <= 0 {
+				report(false)
+			}
+
+			if !yield(idx, item) {
+				return
+			}
+		}
+
+		if iter.Err() != nil {
+			self.err = iter.Err()
+		}
+	}), "Progress", cfg, iter), SizeHintOf(iter))
+}
@@ -0,0 +1,160 @@
+package goiterators_test
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dreadster3/goiterators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressFinalReport(t *testing.T) {
+	iterator := goiterators.NewIteratorFromSlice([]int{1, 2, 3})
+
+	var reports []goiterators.ProgressInfo
+	progress := goiterators.Progress(iterator, time.Hour, func(info goiterators.ProgressInfo) {
+		reports = append(reports, info)
+	})
+
+	assert.Equal(t, []int{1, 2, 3}, slices.Collect(progress.Next))
+	assert.Len(t, reports, 1)
+
+	final := reports[0]
+	assert.True(t, final.Done)
+	assert.Equal(t, 3, final.Count)
+	assert.Equal(t, 3, final.Total, "Expected the total to come from the slice length")
+	assert.Equal(t, float64(100), final.Percent())
+	assert.Zero(t, final.ETA)
+}
+
+func TestProgressEveryItem(t *testing.T) {
+	iterator := goiterators.NewIteratorFromSlice([]int{1, 2, 3, 4})
+
+	var counts []int
+	progress := goiterators.Progress(iterator, 0, func(info goiterators.ProgressInfo) {
+		counts = append(counts, info.Count)
+	})
+
+	_ = slices.Collect(progress.Next)
+
+	assert.Equal(t, []int{1, 2, 3, 4, 4}, counts)
+}
+
+func TestProgressRateAndETA(t *testing.T) {
+	iterator := goiterators.NewIteratorFromSlice([]int{1, 2, 3, 4})
+	slowed := goiterators.Map(iterator, func(x int) int {
+		time.Sleep(10 * time.Millisecond)
+		return x
+	})
+
+	var reports []goiterators.ProgressInfo
+	progress := goiterators.Progress(slowed, 0, func(info goiterators.ProgressInfo) {
+		reports = append(reports, info)
+	}, goiterators.WithTotal(8))
+
+	_ = slices.Collect(goiterators.Take(progress, 2).Next)
+
+	first := reports[0]
+	assert.False(t, first.Done)
+	assert.Equal(t, 8, first.Total)
+	assert.Greater(t, first.Rate, float64(0))
+	assert.Greater(t, first.ETA, time.Duration(0))
+	assert.Equal(t, 12.5, first.Percent())
+
+	final := reports[len(reports)-1]
+	assert.True(t, final.Done)
+	assert.Equal(t, 2, final.Count)
+}
+
+func TestProgressUnknownTotal(t *testing.T) {
+	iterator := goiterators.Filter(goiterators.NewIteratorFromSlice([]int{1, 2, 3}), func(x int) bool { return x > 1 })
+
+	var final goiterators.ProgressInfo
+	progress := goiterators.Progress(iterator, time.Hour, func(info goiterators.ProgressInfo) {
+		final = info
+	})
+
+	_ = slices.Collect(progress.Next)
+
+	assert.Equal(t, 2, final.Count)
+	assert.Zero(t, final.Total)
+	assert.Zero(t, final.ETA)
+	assert.Zero(t, final.Percent())
+}
+
+func TestProgressWithError(t *testing.T) {
+	next := func(yield func(int, error) bool) {
+		if !yield(1, nil) {
+			return
+		}
+		yield(0, errors.New("source error"))
+	}
+
+	var final goiterators.ProgressInfo
+	progress := goiterators.Progress(goiterators.NewIteratorErr(next), time.Hour, func(info goiterators.ProgressInfo) {
+		final = info
+	})
+
+	assert.Equal(t, []int{1}, slices.Collect(progress.Next))
+	assert.EqualError(t, progress.Err(), "source error")
+	assert.True(t, final.Done)
+	assert.Equal(t, 1, final.Count)
+}
+
+func TestProgressInexactSizeHint(t *testing.T) {
+	iterator := goiterators.Distinct(goiterators.NewIteratorFromSlice([]int{1, 2, 3, 4, 5}))
+
+	var final goiterators.ProgressInfo
+	progress := goiterators.Progress(iterator, time.Hour, func(info goiterators.ProgressInfo) {
+		final = info
+	})
+
+	_ = slices.Collect(progress.Next)
+
+	assert.Equal(t, 5, final.Count)
+	assert.Zero(t, final.Total, "Expected an inexact size hint not to be used as the total")
+	assert.Zero(t, final.Percent())
+}
+
+func TestProgressStalledSource(t *testing.T) {
+	release := make(chan struct{})
+	next := func(yield func(int, error) bool) {
+		if !yield(1, nil) {
+			return
+		}
+		<-release // Stall before the second item
+		yield(2, nil)
+	}
+
+	var mu sync.Mutex
+	var reports []goiterators.ProgressInfo
+	progress := goiterators.Progress(goiterators.NewIteratorErr(next), 10*time.Millisecond, func(info goiterators.ProgressInfo) {
+		mu.Lock()
+		defer mu.Unlock()
+		reports = append(reports, info)
+	}, goiterators.WithTotal(2))
+
+	go func() {
+		time.Sleep(60 * time.Millisecond)
+		close(release)
+	}()
+	assert.Equal(t, []int{1, 2}, slices.Collect(progress.Next))
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	// Reports keep coming while the source stalls, with a growing elapsed time
+	stalled := slices.DeleteFunc(slices.Clone(reports), func(info goiterators.ProgressInfo) bool {
+		return info.Done || info.Count != 1
+	})
+	assert.GreaterOrEqual(t, len(stalled), 2)
+	assert.Greater(t, stalled[len(stalled)-1].Elapsed, stalled[0].Elapsed)
+	assert.Greater(t, stalled[0].ETA, time.Duration(0))
+
+	final := reports[len(reports)-1]
+	assert.True(t, final.Done)
+	assert.Equal(t, 2, final.Count)
+}
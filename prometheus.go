@@ -0,0 +1,104 @@
+package goiterators
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// prometheusContentType is the content type of the Prometheus text exposition format
+const prometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+type prometheusMetric struct {
+	name  string
+	help  string
+	kind  string
+	value func(StageStats) string
+}
+
+var prometheusMetrics = []prometheusMetric{
+	{
+		name:  "goiterators_stage_items_in_total",
+		help:  "Items received by the stage.",
+		kind:  "counter",
+		value: func(s StageStats) string { return strconv.FormatUint(s.ItemsIn, 10) },
+	},
+	{
+		name:  "goiterators_stage_items_out_total",
+		help:  "Items successfully processed by the stage.",
+		kind:  "counter",
+		value: func(s StageStats) string { return strconv.FormatUint(s.ItemsOut, 10) },
+	},
+	{
+		name:  "goiterators_stage_errors_total",
+		help:  "Items the stage failed to process.",
+		kind:  "counter",
+		value: func(s StageStats) string { return strconv.FormatUint(s.Errors, 10) },
+	},
+	{
+		name:  "goiterators_stage_workers_started_total",
+		help:  "Async workers started by the stage.",
+		kind:  "counter",
+		value: func(s StageStats) string { return strconv.FormatUint(s.WorkersStarted, 10) },
+	},
+	{
+		name:  "goiterators_stage_workers_in_flight",
+		help:  "Async workers of the stage currently running.",
+		kind:  "gauge",
+		value: func(s StageStats) string { return strconv.FormatInt(s.InFlight, 10) },
+	},
+}
+
+// seconds formats a duration as a number of seconds
+func seconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'g', -1, 64)
+}
+
+// WritePrometheus writes the metrics of every registered stage in the Prometheus text exposition format
+func (c *MetricsCollector) WritePrometheus(w io.Writer) error {
+	stats := c.Snapshot()
+	writer := bufio.NewWriter(w)
+
+	for _, metric := range prometheusMetrics {
+		fmt.Fprintf(writer, "# HELP %s %s\n# TYPE %s %s\n", metric.name, metric.help, metric.name, metric.kind)
+		for _, stage := range stats {
+			fmt.Fprintf(writer, "%s{stage=\"%s\"} %s\n", metric.name, labelEscaper.Replace(stage.Name), metric.value(stage))
+		}
+	}
+
+	const latency = "goiterators_stage_latency_seconds"
+	fmt.Fprintf(writer, "# HELP %s Time taken by the stage to successfully process an item.\n# TYPE %s histogram\n", latency, latency)
+	for _, stage := range stats {
+		label := labelEscaper.Replace(stage.Name)
+		for _, bucket := range stage.Latency.Buckets {
+			fmt.Fprintf(writer, "%s_bucket{stage=\"%s\",le=\"%s\"} %d\n", latency, label, seconds(bucket.UpperBound), bucket.Count)
+		}
+		fmt.Fprintf(writer, "%s_bucket{stage=\"%s\",le=\"+Inf\"} %d\n", latency, label, stage.Latency.Count)
+		fmt.Fprintf(writer, "%s_sum{stage=\"%s\"} %s\n", latency, label, seconds(stage.Latency.Sum))
+		fmt.Fprintf(writer, "%s_count{stage=\"%s\"} %d\n", latency, label, stage.Latency.Count)
+	}
+
+	return writer.Flush()
+}
+
+// ServeHTTP renders the metrics of every registered stage in the Prometheus text exposition format
+func (c *MetricsCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", prometheusContentType)
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	_ = c.WritePrometheus(w)
+}
@@ -0,0 +1,81 @@
+package goiterators_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dreadster3/goiterators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsCollectorPrometheus(t *testing.T) {
+	collector := goiterators.NewMetricsCollector(10*time.Millisecond, time.Second)
+
+	stage := collector.Stage("fetch")
+	stage.OnItemIn(0)
+	stage.OnItemIn(1)
+	stage.OnWorkerStart(0)
+	stage.OnWorkerStart(1)
+	stage.OnItemOut(0, 5*time.Millisecond)
+	stage.OnError(1, nil)
+	stage.OnWorkerStop(0, 5*time.Millisecond)
+
+	collector.Stage(`say "hi"`).OnItemIn(0)
+
+	server := httptest.NewServer(collector)
+	defer server.Close()
+
+	response, err := http.Get(server.URL)
+	assert.NoError(t, err)
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	assert.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", response.Header.Get("Content-Type"))
+
+	expected := []string{
+		"# TYPE goiterators_stage_items_in_total counter",
+		`goiterators_stage_items_in_total{stage="fetch"} 2`,
+		`goiterators_stage_items_out_total{stage="fetch"} 1`,
+		`goiterators_stage_errors_total{stage="fetch"} 1`,
+		`goiterators_stage_workers_started_total{stage="fetch"} 2`,
+		"# TYPE goiterators_stage_workers_in_flight gauge",
+		`goiterators_stage_workers_in_flight{stage="fetch"} 1`,
+		"# TYPE goiterators_stage_latency_seconds histogram",
+		`goiterators_stage_latency_seconds_bucket{stage="fetch",le="0.01"} 1`,
+		`goiterators_stage_latency_seconds_bucket{stage="fetch",le="1"} 1`,
+		`goiterators_stage_latency_seconds_bucket{stage="fetch",le="+Inf"} 1`,
+		`goiterators_stage_latency_seconds_sum{stage="fetch"} 0.005`,
+		`goiterators_stage_latency_seconds_count{stage="fetch"} 1`,
+		`goiterators_stage_items_in_total{stage="say \"hi\""} 1`,
+	}
+
+	lines := strings.Split(string(body), "\n")
+	for _, line := range expected {
+		assert.Contains(t, lines, line)
+	}
+}
+
+func TestMetricsCollectorPrometheusMethodNotAllowed(t *testing.T) {
+	collector := goiterators.NewMetricsCollector()
+
+	recorder := httptest.NewRecorder()
+	collector.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/metrics", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
+
+func TestMetricsCollectorPrometheusEmpty(t *testing.T) {
+	collector := goiterators.NewMetricsCollector()
+
+	var builder strings.Builder
+	assert.NoError(t, collector.WritePrometheus(&builder))
+	assert.Contains(t, builder.String(), "# TYPE goiterators_stage_items_in_total counter")
+	assert.NotContains(t, builder.String(), "stage=")
+}
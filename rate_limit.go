@@ -0,0 +1,126 @@
+package goiterators
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// tokenBucket limits events to a steady rate while allowing short bursts
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBucket creates a full token bucket, returning nil when rate is not positive
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if rate <= 0 {
+		return nil
+	}
+
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token and returns how long the caller must wait before using it
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+
+	b.tokens -= 1
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel returns a reserved token that was never used
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens += 1
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
+
+// Wait blocks until a token is available or the context is done
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	delay := b.reserve()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		b.cancel()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// WithRateLimit limits calls to the item function to rate per second across all workers, allowing bursts of up to burst calls
+// Items are only dispatched to a worker once a token is available, so no goroutines are left blocked waiting for the limiter
+// A non-positive rate disables the limit
+func WithRateLimit(rate float64, burst int) Option {
+	return func(c *config) {
+		c.limiter = newTokenBucket(rate, burst)
+	}
+}
+
+// RateLimit yields at most rate items per second, allowing bursts of up to burst items
+func RateLimit[T any](iter Iterator[T], rate float64, burst int) Iterator[T] {
+	return RateLimitCtx(context.Background(), iter, rate, burst)
+}
+
+// RateLimitCtx yields at most rate items per second, allowing bursts of up to burst items, with context cancellation
+func RateLimitCtx[T any](ctx context.Context, iter Iterator[T], rate float64, burst int) Iterator[T] {
+	limiter := newTokenBucket(rate, burst)
+
+	return withSize(withStage(newIterator(func(self *iterator[T], yield func(int, T) bool) {
+		for idx, item := range iter.INext {
+			if limiter != nil {
+				if err := limiter.Wait(ctx); err != nil {
+					self.err = err
+					return
+				}
+			}
+
+			if !yield(idx, item) {
+				return
+			}
+		}
+
+		if iter.Err() != nil {
+			self.err = iter.Err()
+		}
+	}), "RateLimit", nil, iter), SizeHintOf(iter))
+}
@@ -0,0 +1,118 @@
+package goiterators_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dreadster3/goiterators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimit(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5}
+	iterator := goiterators.NewIteratorFromSlice(data)
+
+	start := time.Now()
+	limited := goiterators.RateLimit(iterator, 50, 1)
+	result := slices.Collect(limited.Next)
+	elapsed := time.Since(start)
+
+	assert.Equal(t, data, result)
+	assert.NoError(t, limited.Err())
+	// First item is immediate, remaining 4 are spaced 20ms apart
+	assert.GreaterOrEqual(t, elapsed, 70*time.Millisecond)
+}
+
+func TestRateLimitBurst(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5}
+	iterator := goiterators.NewIteratorFromSlice(data)
+
+	start := time.Now()
+	limited := goiterators.RateLimit(iterator, 1, 5)
+	result := slices.Collect(limited.Next)
+	elapsed := time.Since(start)
+
+	assert.Equal(t, data, result)
+	assert.Less(t, elapsed, 100*time.Millisecond, "Expected burst to be served immediately")
+}
+
+func TestRateLimitCtxCancellation(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5}
+	iterator := goiterators.NewIteratorFromSlice(data)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	limited := goiterators.RateLimitCtx(ctx, iterator, 1, 1)
+	result := slices.Collect(limited.Next)
+
+	assert.Equal(t, []int{1}, result)
+	assert.Equal(t, context.DeadlineExceeded, limited.Err())
+}
+
+func TestRateLimitWithError(t *testing.T) {
+	next := func(yield func(int, error) bool) {
+		if !yield(1, nil) {
+			return
+		}
+		yield(0, errors.New("source error"))
+	}
+
+	limited := goiterators.RateLimit(goiterators.NewIteratorErr(next), 100, 1)
+	result := slices.Collect(limited.Next)
+
+	assert.Equal(t, []int{1}, result)
+	assert.EqualError(t, limited.Err(), "source error")
+}
+
+func TestMapAsyncCtxWithRateLimit(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6}
+	iterator := goiterators.NewIteratorFromSlice(data)
+
+	var mu sync.Mutex
+	var calls []time.Time
+
+	start := time.Now()
+	mapped := goiterators.MapAsyncCtx(context.Background(), iterator, func(ctx context.Context, x int) (int, error) {
+		mu.Lock()
+		calls = append(calls, time.Now())
+		mu.Unlock()
+		return x * 2, nil
+	}, goiterators.WithRateLimit(50, 2))
+
+	result := slices.Collect(mapped.Next)
+	elapsed := time.Since(start)
+	slices.Sort(result)
+
+	assert.Equal(t, []int{2, 4, 6, 8, 10, 12}, result)
+	assert.NoError(t, mapped.Err())
+	assert.Len(t, calls, len(data))
+	// Burst of 2, remaining 4 are spaced 20ms apart
+	assert.GreaterOrEqual(t, elapsed, 70*time.Millisecond)
+}
+
+func TestForEachAsyncCtxWithRateLimitCancellation(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5}
+	iterator := goiterators.NewIteratorFromSlice(data)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	var mu sync.Mutex
+	processed := 0
+
+	start := time.Now()
+	err := goiterators.ForEachAsyncCtx(ctx, iterator, func(ctx context.Context, x int) error {
+		mu.Lock()
+		processed++
+		mu.Unlock()
+		return nil
+	}, goiterators.WithRateLimit(1, 1))
+	elapsed := time.Since(start)
+
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, 1, processed)
+	assert.Less(t, elapsed, 500*time.Millisecond, "Expected limiter wait to honor cancellation")
+}
@@ -0,0 +1,128 @@
+package goiterators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy configures how failed item functions are retried
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one, values below 1 are treated as 1
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between attempts, zero means no cap
+	MaxBackoff time.Duration
+	// Multiplier grows the delay after every retry, values below 1 are treated as 2
+	Multiplier float64
+	// Jitter randomises every delay by up to the given fraction of itself, between 0 and 1
+	Jitter float64
+	// AttemptTimeout bounds the duration of every attempt, zero means no timeout
+	AttemptTimeout time.Duration
+	// Retryable reports whether an error is transient and worth retrying, nil retries every error except ErrCircuitOpen and PanicError
+	Retryable func(error) bool
+}
+
+// RetryError is returned when an item function still fails after being retried
+type RetryError struct {
+	Index    int
+	Attempts int
+	Err      error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("item %d failed after %d attempt(s): %v", e.Index, e.Attempts, e.Err)
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// WithRetry retries failed item functions according to the policy
+// The final error of an item is wrapped in a RetryError recording the number of attempts made
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *config) {
+		c.retry = &policy
+	}
+}
+
+// backoff returns the delay to wait before the given retry, starting at 1
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+
+	delay := float64(p.InitialBackoff) * math.Pow(multiplier, float64(retry-1))
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		jitter := min(p.Jitter, 1)
+		delay += delay * jitter * (2*rand.Float64() - 1)
+	}
+
+	return time.Duration(delay)
+}
+
+// retryable reports whether err is worth retrying
+func (p *RetryPolicy) retryable(err error) bool {
+	if p.Retryable == nil {
+		var panicErr *PanicError
+		return !errors.Is(err, ErrCircuitOpen) && !errors.As(err, &panicErr)
+	}
+
+	return p.Retryable(err)
+}
+
+// attempt runs fn once, bounded by the attempt timeout
+func (p *RetryPolicy) attempt(ctx context.Context, fn callFunc) error {
+	if p.AttemptTimeout <= 0 {
+		return fn(ctx)
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, p.AttemptTimeout)
+	defer cancel()
+
+	return fn(attemptCtx)
+}
+
+// wrap returns a callFunc retrying fn for the item at idx, every retry waits on limiter when it is not nil
+// The first attempt is not limited here as the item already took a token when it was dispatched
+func (p *RetryPolicy) wrap(idx int, limiter *tokenBucket, fn callFunc) callFunc {
+	return func(ctx context.Context) error {
+		maxAttempts := max(p.MaxAttempts, 1)
+
+		attempts := 0
+		for {
+			attempts++
+			err := p.attempt(ctx, fn)
+			if err == nil {
+				return nil
+			}
+
+			if attempts >= maxAttempts || ctx.Err() != nil || !p.retryable(err) {
+				return &RetryError{Index: idx, Attempts: attempts, Err: err}
+			}
+
+			timer := time.NewTimer(p.backoff(attempts))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return &RetryError{Index: idx, Attempts: attempts, Err: ctx.Err()}
+			case <-timer.C:
+			}
+
+			if limiter != nil {
+				if err := limiter.Wait(ctx); err != nil {
+					return &RetryError{Index: idx, Attempts: attempts, Err: err}
+				}
+			}
+		}
+	}
+}
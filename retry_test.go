@@ -0,0 +1,154 @@
+package goiterators_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dreadster3/goiterators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIMapAsyncCtxWithRetry(t *testing.T) {
+	data := []int{1, 2, 3}
+	iterator := goiterators.NewIteratorFromSlice(data)
+
+	var mu sync.Mutex
+	attempts := map[int]int{}
+
+	mapped := goiterators.IMapAsyncCtx(context.Background(), iterator, func(ctx context.Context, idx int, x int) (int, error) {
+		mu.Lock()
+		attempts[idx]++
+		current := attempts[idx]
+		mu.Unlock()
+
+		if current < 3 {
+			return 0, errors.New("transient")
+		}
+		return x * 10, nil
+	}, goiterators.WithRetry(goiterators.RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+	}))
+
+	result := slices.Collect(mapped.Next)
+	slices.Sort(result)
+
+	assert.Equal(t, []int{10, 20, 30}, result)
+	assert.NoError(t, mapped.Err())
+	assert.Equal(t, map[int]int{0: 3, 1: 3, 2: 3}, attempts)
+}
+
+func TestIMapAsyncCtxWithRetryExhausted(t *testing.T) {
+	errTransient := errors.New("transient")
+	iterator := goiterators.NewIteratorFromSlice([]int{1})
+
+	var calls atomic.Int32
+	mapped := goiterators.IMapAsyncCtx(context.Background(), iterator, func(ctx context.Context, idx int, x int) (int, error) {
+		calls.Add(1)
+		return 0, errTransient
+	}, goiterators.WithRetry(goiterators.RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: time.Millisecond,
+		Multiplier:     2,
+		Jitter:         0.5,
+	}))
+
+	result := slices.Collect(mapped.Next)
+	assert.Empty(t, result)
+
+	var retryErr *goiterators.RetryError
+	assert.ErrorAs(t, mapped.Err(), &retryErr)
+	assert.Equal(t, 0, retryErr.Index)
+	assert.Equal(t, 4, retryErr.Attempts)
+	assert.ErrorIs(t, mapped.Err(), errTransient)
+	assert.Equal(t, int32(4), calls.Load())
+}
+
+func TestIFilterAsyncCtxWithRetryPermanentError(t *testing.T) {
+	errPermanent := errors.New("permanent")
+	iterator := goiterators.NewIteratorFromSlice([]int{1})
+
+	var calls atomic.Int32
+	filtered := goiterators.IFilterAsyncCtx(context.Background(), iterator, func(ctx context.Context, idx int, x int) (bool, error) {
+		calls.Add(1)
+		return false, errPermanent
+	}, goiterators.WithRetry(goiterators.RetryPolicy{
+		MaxAttempts: 5,
+		Retryable: func(err error) bool {
+			return !errors.Is(err, errPermanent)
+		},
+	}))
+
+	_ = slices.Collect(filtered.Next)
+
+	var retryErr *goiterators.RetryError
+	assert.ErrorAs(t, filtered.Err(), &retryErr)
+	assert.Equal(t, 1, retryErr.Attempts)
+	assert.ErrorIs(t, filtered.Err(), errPermanent)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestIForEachAsyncCtxWithRetryAttemptTimeout(t *testing.T) {
+	iterator := goiterators.NewIteratorFromSlice([]int{1})
+
+	var calls atomic.Int32
+	err := goiterators.IForEachAsyncCtx(context.Background(), iterator, func(ctx context.Context, idx int, x int) error {
+		if calls.Add(1) == 1 {
+			<-ctx.Done() // First attempt hangs until its timeout
+			return ctx.Err()
+		}
+		return nil
+	}, goiterators.WithRetry(goiterators.RetryPolicy{
+		MaxAttempts:    2,
+		AttemptTimeout: 20 * time.Millisecond,
+	}))
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestIForEachAsyncCtxWithRetryCancellation(t *testing.T) {
+	iterator := goiterators.NewIteratorFromSlice([]int{1})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := goiterators.IForEachAsyncCtx(ctx, iterator, func(ctx context.Context, idx int, x int) error {
+		return errors.New("transient")
+	}, goiterators.WithRetry(goiterators.RetryPolicy{
+		MaxAttempts:    10,
+		InitialBackoff: time.Second,
+	}))
+	elapsed := time.Since(start)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, elapsed, 500*time.Millisecond, "Expected backoff to abort when context is done")
+}
+
+func TestMapAsyncCtxRetryWithRateLimit(t *testing.T) {
+	iterator := goiterators.NewIteratorFromSlice([]int{1})
+
+	var mu sync.Mutex
+	var calls []time.Time
+	mapped := goiterators.MapAsyncCtx(context.Background(), iterator, func(ctx context.Context, x int) (int, error) {
+		mu.Lock()
+		calls = append(calls, time.Now())
+		mu.Unlock()
+		return 0, errors.New("transient")
+	}, goiterators.WithRateLimit(50, 1), goiterators.WithRetry(goiterators.RetryPolicy{MaxAttempts: 4}))
+
+	_ = slices.Collect(mapped.Next)
+
+	var retryErr *goiterators.RetryError
+	assert.ErrorAs(t, mapped.Err(), &retryErr)
+	assert.Equal(t, 4, retryErr.Attempts)
+
+	// Every retry takes a token, so the 4 calls are spaced 20ms apart
+	assert.Len(t, calls, 4)
+	assert.GreaterOrEqual(t, calls[len(calls)-1].Sub(calls[0]), 55*time.Millisecond)
+}
@@ -0,0 +1,102 @@
+package goiterators_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/dreadster3/goiterators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReverse(t *testing.T) {
+	data := []string{"a", "b", "c", "d"}
+	sources := map[string]goiterators.Iterator[string]{
+		"slice":     goiterators.NewIteratorFromSlice(data),
+		"unindexed": unindexed(data),
+	}
+
+	for name, source := range sources {
+		t.Run(name, func(t *testing.T) {
+			reversed := goiterators.Reverse(source)
+
+			indices, items := collectIndexed(reversed)
+			assert.Equal(t, []string{"d", "c", "b", "a"}, items)
+			assert.Equal(t, []int{3, 2, 1, 0}, indices)
+			assert.NoError(t, reversed.Err())
+			assert.Equal(t, goiterators.SizeHintOf(source), goiterators.SizeHintOf(reversed))
+		})
+	}
+}
+
+func TestReverseTwice(t *testing.T) {
+	data := []int{1, 2, 3}
+
+	reversed := goiterators.Reverse(goiterators.Reverse(goiterators.NewIteratorFromSlice(data)))
+
+	indices, items := collectIndexed(reversed)
+	assert.Equal(t, data, items)
+	assert.Equal(t, []int{0, 1, 2}, indices)
+}
+
+func TestReverseSliceOperations(t *testing.T) {
+	reversed := goiterators.Reverse(goiterators.NewIteratorFromSlice([]int{1, 2, 3, 4, 5, 6}))
+
+	indices, items := collectIndexed(goiterators.Take(goiterators.Skip(reversed, 1), 2))
+	assert.Equal(t, []int{5, 4}, items)
+	assert.Equal(t, []int{4, 3}, indices)
+
+	indices, items = collectIndexed(goiterators.TakeLast(reversed, 2))
+	assert.Equal(t, []int{2, 1}, items)
+	assert.Equal(t, []int{1, 0}, indices)
+
+	// Reversing a window of the slice reverses only that window
+	window := goiterators.Reverse(goiterators.Skip(goiterators.NewIteratorFromSlice([]int{1, 2, 3, 4}), 2))
+	indices, items = collectIndexed(window)
+	assert.Equal(t, []int{4, 3}, items)
+	assert.Equal(t, []int{3, 2}, indices)
+}
+
+func TestReverseWithError(t *testing.T) {
+	next := func(yield func(int, error) bool) {
+		if !yield(1, nil) {
+			return
+		}
+		yield(0, errors.New("source error"))
+	}
+
+	reversed := goiterators.Reverse(goiterators.NewIteratorErr(next))
+
+	assert.Empty(t, slices.Collect(reversed.Next))
+	assert.EqualError(t, reversed.Err(), "source error")
+}
+
+func TestReverseAfterFilter(t *testing.T) {
+	filtered := goiterators.Filter(goiterators.NewIteratorFromSlice([]int{1, 2, 3, 4}), func(x int) bool { return x%2 == 0 })
+
+	indices, items := collectIndexed(goiterators.Reverse(filtered))
+	assert.Equal(t, []int{4, 2}, items)
+	assert.Equal(t, []int{3, 1}, indices)
+}
+
+func TestMapAsyncReversedChunked(t *testing.T) {
+	reversed := goiterators.Reverse(goiterators.NewIteratorFromSlice([]int{1, 2, 3, 4, 5}))
+
+	mapped := goiterators.IMapAsyncCtx(context.Background(), reversed, func(ctx context.Context, idx int, x int) (int, error) {
+		return idx*10 + x, nil
+	}, goiterators.WithConcurrency(2), goiterators.WithOrdered())
+
+	result, err := goiterators.Collect(mapped)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{45, 34, 23, 12, 1}, result)
+}
+
+func TestTakeAfterReverseUnindexed(t *testing.T) {
+	identity := func(x int) int { return x }
+	mapped := goiterators.Map(goiterators.NewIteratorFromSlice([]int{1, 2, 3, 4, 5}), identity)
+
+	indices, items := collectIndexed(goiterators.Take(goiterators.Reverse(mapped), 2))
+	assert.Equal(t, []int{5, 4}, items)
+	assert.Equal(t, []int{4, 3}, indices)
+}
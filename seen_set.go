@@ -0,0 +1,113 @@
+package goiterators
+
+import (
+	"hash/maphash"
+	"math"
+	"sync"
+)
+
+// SeenSet records the keys that have already been seen, all implementations are safe for concurrent use
+type SeenSet[K comparable] interface {
+	// Add records key and reports whether it had not been seen before
+	Add(key K) bool
+}
+
+type mapSet[K comparable] struct {
+	mu   sync.Mutex
+	seen map[K]struct{}
+}
+
+// NewMapSet creates an exact SeenSet that remembers every key
+func NewMapSet[K comparable]() SeenSet[K] {
+	return &mapSet[K]{
+		seen: make(map[K]struct{}),
+	}
+}
+
+func (s *mapSet[K]) Add(key K) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.seen[key]; ok {
+		return false
+	}
+
+	s.seen[key] = struct{}{}
+	return true
+}
+
+type lruSet[K comparable] struct {
+	mu   sync.Mutex
+	keys *lru[K, struct{}]
+}
+
+// NewLRUSet creates a SeenSet that remembers only the capacity most recently seen keys
+// A key is reported as new again once more than capacity other keys were seen since its last occurrence
+func NewLRUSet[K comparable](capacity int) SeenSet[K] {
+	return &lruSet[K]{
+		keys: newLRU[K, struct{}](capacity),
+	}
+}
+
+func (s *lruSet[K]) Add(key K) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.keys.get(key); ok {
+		return false
+	}
+
+	s.keys.put(key, struct{}{})
+	return true
+}
+
+type bloomSet[K comparable] struct {
+	mu     sync.Mutex
+	bits   []uint64
+	size   uint64
+	hashes int
+	seeds  [2]maphash.Seed
+}
+
+// NewBloomSet creates an approximate SeenSet backed by a Bloom filter sized for expected keys
+// Memory stays constant, but once expected keys were added a new key is wrongly reported as seen
+// with a probability close to falsePositiveRate
+func NewBloomSet[K comparable](expected int, falsePositiveRate float64) SeenSet[K] {
+	if expected < 1 {
+		expected = 1
+	}
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		falsePositiveRate = 0.01
+	}
+
+	size := math.Ceil(-float64(expected) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	hashes := max(int(math.Round(size/float64(expected)*math.Ln2)), 1)
+
+	words := (uint64(size) + 63) / 64
+	return &bloomSet[K]{
+		bits:   make([]uint64, words),
+		size:   words * 64,
+		hashes: hashes,
+		seeds:  [2]maphash.Seed{maphash.MakeSeed(), maphash.MakeSeed()},
+	}
+}
+
+func (s *bloomSet[K]) Add(key K) bool {
+	h1 := maphash.Comparable(s.seeds[0], key)
+	h2 := maphash.Comparable(s.seeds[1], key) | 1
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	added := false
+	for i := range s.hashes {
+		bit := (h1 + uint64(i)*h2) % s.size
+		word, mask := bit/64, uint64(1)<<(bit%64)
+		if s.bits[word]&mask == 0 {
+			s.bits[word] |= mask
+			added = true
+		}
+	}
+
+	return added
+}
@@ -0,0 +1,66 @@
+package goiterators_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dreadster3/goiterators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapSet(t *testing.T) {
+	set := goiterators.NewMapSet[string]()
+
+	assert.True(t, set.Add("a"))
+	assert.True(t, set.Add("b"))
+	assert.False(t, set.Add("a"))
+	assert.False(t, set.Add("b"))
+}
+
+func TestLRUSet(t *testing.T) {
+	set := goiterators.NewLRUSet[int](2)
+
+	assert.True(t, set.Add(1))
+	assert.True(t, set.Add(2))
+	assert.False(t, set.Add(1)) // 1 becomes most recently seen
+	assert.True(t, set.Add(3))  // evicts 2
+	assert.False(t, set.Add(1))
+	assert.True(t, set.Add(2))
+}
+
+func TestBloomSet(t *testing.T) {
+	const expected = 10000
+	set := goiterators.NewBloomSet[string](expected, 0.01)
+
+	falsePositives := 0
+	for i := range expected {
+		if !set.Add(fmt.Sprintf("event-%d", i)) {
+			falsePositives++
+		}
+	}
+
+	// No false negatives: every key added is reported as seen
+	for i := range expected {
+		assert.False(t, set.Add(fmt.Sprintf("event-%d", i)))
+	}
+
+	assert.Less(t, falsePositives, expected/50, "Expected false positive rate close to one percent")
+}
+
+func TestDistinctWithBloomSet(t *testing.T) {
+	data := []int{}
+	for i := range 1000 {
+		data = append(data, i, i)
+	}
+
+	set := goiterators.NewBloomSet[int](1000, 0.001)
+	distinct := goiterators.DistinctWith(goiterators.NewIteratorFromSlice(data), func(x int) int { return x }, set)
+
+	count := 0
+	for range distinct.Next {
+		count++
+	}
+
+	assert.LessOrEqual(t, count, 1000)
+	assert.Greater(t, count, 990)
+}
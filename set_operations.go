@@ -0,0 +1,181 @@
+package goiterators
+
+import "iter"
+
+// cursor pulls items one at a time from an iterator
+type cursor[T any] struct {
+	source Iterator[T]
+	next   func() (T, bool)
+	stop   func()
+	item   T
+	ok     bool
+}
+
+// newCursor creates a cursor positioned on the first item of source, stop must be called once done
+func newCursor[T any](source Iterator[T]) *cursor[T] {
+	next, stop := iter.Pull(source.Next)
+	c := &cursor[T]{
+		source: source,
+		next:   next,
+		stop:   stop,
+	}
+	c.advance()
+
+	return c
+}
+
+// advance moves the cursor to the next item
+func (c *cursor[T]) advance() {
+	c.item, c.ok = c.next()
+}
+
+// err returns the error of the source once the cursor is exhausted
+func (c *cursor[T]) err() error {
+	if c.ok {
+		return nil
+	}
+
+	return c.source.Err()
+}
+
+// setOperation merges two sorted iterators, yielding items found only in left, only in right or in both as requested
+func setOperation[T any](left, right Iterator[T], cmp func(a, b T) int, onlyLeft, onlyRight, both bool) Iterator[T] {
+	return newIterator(func(self *iterator[T], yield func(int, T) bool) {
+		l := newCursor(left)
+		defer l.stop()
+		r := newCursor(right)
+		defer r.stop()
+
+		idx := 0
+		emit := func(item T) bool {
+			if !yield(idx, item) {
+				return false
+			}
+			idx++
+			return true
+		}
+
+		for {
+			if err := l.err(); err != nil {
+				self.err = err
+				return
+			}
+			if err := r.err(); err != nil {
+				self.err = err
+				return
+			}
+
+			switch {
+			case !l.ok && !r.ok:
+				return
+			case !r.ok:
+				if !onlyLeft || !emit(l.item) {
+					return
+				}
+				l.advance()
+			case !l.ok:
+				if !onlyRight || !emit(r.item) {
+					return
+				}
+				r.advance()
+			default:
+				c := cmp(l.item, r.item)
+				switch {
+				case c < 0:
+					if onlyLeft && !emit(l.item) {
+						return
+					}
+					l.advance()
+				case c > 0:
+					if onlyRight && !emit(r.item) {
+						return
+					}
+					r.advance()
+				default:
+					if both && !emit(l.item) {
+						return
+					}
+					l.advance()
+					r.advance()
+				}
+			}
+		}
+	})
+}
+
+// Union yields the items found in either sorted iterator, equal items present in both are yielded once from left
+func Union[T any](left, right Iterator[T], cmp func(a, b T) int) Iterator[T] {
+	return withStage(setOperation(left, right, cmp, true, true, true), "Union", nil, left, right)
+}
+
+// Intersect yields the items of left also found in the sorted right iterator
+func Intersect[T any](left, right Iterator[T], cmp func(a, b T) int) Iterator[T] {
+	return withStage(setOperation(left, right, cmp, false, false, true), "Intersect", nil, left, right)
+}
+
+// Difference yields the items of left not found in the sorted right iterator
+func Difference[T any](left, right Iterator[T], cmp func(a, b T) int) Iterator[T] {
+	return withStage(setOperation(left, right, cmp, true, false, false), "Difference", nil, left, right)
+}
+
+// SymmetricDifference yields the items found in exactly one of the sorted iterators
+func SymmetricDifference[T any](left, right Iterator[T], cmp func(a, b T) int) Iterator[T] {
+	return withStage(setOperation(left, right, cmp, true, true, false), "SymmetricDifference", nil, left, right)
+}
+
+// IntersectAll yields the items found in every sorted iterator, taking the item from the first one
+func IntersectAll[T any](cmp func(a, b T) int, its ...Iterator[T]) Iterator[T] {
+	return withStage(newIterator(func(self *iterator[T], yield func(int, T) bool) {
+		if len(its) == 0 {
+			return
+		}
+
+		cursors := make([]*cursor[T], len(its))
+		for i, it := range its {
+			cursors[i] = newCursor(it)
+			defer cursors[i].stop()
+		}
+
+		idx := 0
+		for {
+			// Find the greatest head, every other cursor must catch up to it
+			target := cursors[0]
+			for _, c := range cursors {
+				if !c.ok {
+					self.err = c.err()
+					return
+				}
+				if cmp(c.item, target.item) > 0 {
+					target = c
+				}
+			}
+
+			aligned := true
+			for _, c := range cursors {
+				for c.ok && cmp(c.item, target.item) < 0 {
+					c.advance()
+				}
+				if !c.ok {
+					self.err = c.err()
+					return
+				}
+				if cmp(c.item, target.item) != 0 {
+					aligned = false
+				}
+			}
+
+			if !aligned {
+				continue
+			}
+
+			if !yield(idx, cursors[0].item) {
+				return
+			}
+			idx++
+
+			for _, c := range cursors {
+				c.advance()
+			}
+		}
+	}), "IntersectAll", nil, inputsOf(its)...)
+}
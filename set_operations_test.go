@@ -0,0 +1,121 @@
+package goiterators_test
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/dreadster3/goiterators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnion(t *testing.T) {
+	left := goiterators.NewIteratorFromSlice([]int{1, 3, 5, 7})
+	right := goiterators.NewIteratorFromSlice([]int{2, 3, 6, 7, 9})
+
+	union := goiterators.Union(left, right, cmp.Compare[int])
+
+	assert.Equal(t, []int{1, 2, 3, 5, 6, 7, 9}, slices.Collect(union.Next))
+	assert.NoError(t, union.Err())
+}
+
+func TestIntersect(t *testing.T) {
+	left := goiterators.NewIteratorFromSlice([]int{1, 3, 5, 7})
+	right := goiterators.NewIteratorFromSlice([]int{2, 3, 6, 7, 9})
+
+	intersection := goiterators.Intersect(left, right, cmp.Compare[int])
+
+	var indices []int
+	var result []int
+	for idx, item := range intersection.INext {
+		indices = append(indices, idx)
+		result = append(result, item)
+	}
+
+	assert.Equal(t, []int{3, 7}, result)
+	assert.Equal(t, []int{0, 1}, indices)
+	assert.NoError(t, intersection.Err())
+}
+
+func TestDifference(t *testing.T) {
+	left := goiterators.NewIteratorFromSlice([]int{1, 3, 5, 7, 8})
+	right := goiterators.NewIteratorFromSlice([]int{2, 3, 6, 7, 9})
+
+	difference := goiterators.Difference(left, right, cmp.Compare[int])
+
+	assert.Equal(t, []int{1, 5, 8}, slices.Collect(difference.Next))
+	assert.NoError(t, difference.Err())
+}
+
+func TestSymmetricDifference(t *testing.T) {
+	left := goiterators.NewIteratorFromSlice([]int{1, 3, 5, 7})
+	right := goiterators.NewIteratorFromSlice([]int{2, 3, 6, 7, 9})
+
+	difference := goiterators.SymmetricDifference(left, right, cmp.Compare[int])
+
+	assert.Equal(t, []int{1, 2, 5, 6, 9}, slices.Collect(difference.Next))
+	assert.NoError(t, difference.Err())
+}
+
+func TestSetOperationsEmpty(t *testing.T) {
+	empty := func() goiterators.Iterator[int] { return goiterators.NewIteratorFromSlice([]int{}) }
+	values := func() goiterators.Iterator[int] { return goiterators.NewIteratorFromSlice([]int{1, 2}) }
+
+	assert.Equal(t, []int{1, 2}, slices.Collect(goiterators.Union(empty(), values(), cmp.Compare[int]).Next))
+	assert.Empty(t, slices.Collect(goiterators.Intersect(values(), empty(), cmp.Compare[int]).Next))
+	assert.Equal(t, []int{1, 2}, slices.Collect(goiterators.Difference(values(), empty(), cmp.Compare[int]).Next))
+	assert.Empty(t, slices.Collect(goiterators.Difference(empty(), values(), cmp.Compare[int]).Next))
+}
+
+func TestSetOperationWithError(t *testing.T) {
+	next := func(yield func(int, error) bool) {
+		if !yield(2, nil) {
+			return
+		}
+		yield(0, errors.New("right error"))
+	}
+
+	left := goiterators.NewIteratorFromSlice([]int{1, 2, 3, 4})
+	union := goiterators.Union(left, goiterators.NewIteratorErr(next), cmp.Compare[int])
+
+	result := slices.Collect(union.Next)
+
+	assert.Equal(t, []int{1, 2}, result)
+	assert.EqualError(t, union.Err(), "right error")
+}
+
+func TestIntersectAll(t *testing.T) {
+	first := goiterators.NewIteratorFromSlice([]int{1, 2, 3, 5, 8, 13})
+	second := goiterators.NewIteratorFromSlice([]int{2, 3, 4, 5, 8, 9, 13})
+	third := goiterators.NewIteratorFromSlice([]int{0, 2, 5, 6, 13, 14})
+
+	intersection := goiterators.IntersectAll(cmp.Compare[int], first, second, third)
+
+	assert.Equal(t, []int{2, 5, 13}, slices.Collect(intersection.Next))
+	assert.NoError(t, intersection.Err())
+}
+
+func TestIntersectAllWithError(t *testing.T) {
+	next := func(yield func(int, error) bool) {
+		if !yield(1, nil) {
+			return
+		}
+		yield(0, errors.New("source error"))
+	}
+
+	intersection := goiterators.IntersectAll(cmp.Compare[int],
+		goiterators.NewIteratorFromSlice([]int{1, 2, 3}),
+		goiterators.NewIteratorErr(next),
+	)
+
+	assert.Equal(t, []int{1}, slices.Collect(intersection.Next))
+	assert.EqualError(t, intersection.Err(), "source error")
+}
+
+func TestIntersectAllNoIterators(t *testing.T) {
+	intersection := goiterators.IntersectAll(cmp.Compare[int])
+
+	assert.Empty(t, slices.Collect(intersection.Next))
+	assert.NoError(t, intersection.Err())
+}
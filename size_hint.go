@@ -0,0 +1,90 @@
+package goiterators
+
+// SizeHint bounds the number of items an iterator yields when it does not fail
+// The zero value is the hint of an iterator with an unknown number of items
+type SizeHint struct {
+	// Min is the minimum number of items
+	Min int
+	// Max is the maximum number of items when Bounded is set
+	Max     int
+	Bounded bool
+}
+
+// ExactSize returns the hint of an iterator yielding exactly n items
+func ExactSize(n int) SizeHint {
+	return SizeHint{Min: n, Max: n, Bounded: true}
+}
+
+// Exact returns the number of items when the hint is exact
+func (h SizeHint) Exact() (int, bool) {
+	return h.Min, h.Bounded && h.Min == h.Max
+}
+
+// upTo returns the hint of an iterator yielding at most the items of h
+func (h SizeHint) upTo() SizeHint {
+	return SizeHint{Max: h.Max, Bounded: h.Bounded}
+}
+
+// distinct returns the hint of the distinct items of h, at least one item remains from a non-empty iterator
+func (h SizeHint) distinct() SizeHint {
+	return SizeHint{Min: min(h.Min, 1), Max: h.Max, Bounded: h.Bounded}
+}
+
+// limit returns the hint of the first n items of h
+func (h SizeHint) limit(n int) SizeHint {
+	n = max(n, 0)
+	if h.Bounded {
+		n = min(n, h.Max)
+	}
+
+	return SizeHint{Min: min(h.Min, n), Max: n, Bounded: true}
+}
+
+// Sized is implemented by iterators knowing bounds of their number of items, such as the iterators of this package
+// Async algorithms rely on the bounds to size their buffers, an iterator must never yield more than a bounded Max
+type Sized interface {
+	SizeHint() SizeHint
+}
+
+// SizeHintOf returns the size hint of iter, or the zero SizeHint when iter does not implement Sized
+func SizeHintOf[T any](iter Iterator[T]) SizeHint {
+	if sized, ok := iter.(Sized); ok {
+		return sized.SizeHint()
+	}
+
+	return SizeHint{}
+}
+
+func (it *iterator[T]) SizeHint() SizeHint {
+	return it.size
+}
+
+func (it *asyncIterator[T]) SizeHint() SizeHint {
+	return it.size
+}
+
+func (it *iterator[T]) setSize(size SizeHint)      { it.size = size }
+func (it *asyncIterator[T]) setSize(size SizeHint) { it.size = size }
+
+// withSize records the size hint of it
+func withSize[I any](it I, size SizeHint) I {
+	if sized, ok := any(it).(interface{ setSize(SizeHint) }); ok {
+		sized.setSize(size)
+	}
+
+	return it
+}
+
+// Collect gathers every item into a slice preallocated for the minimum number of items of iter, returning the items gathered before an error
+func Collect[T any](iter Iterator[T]) ([]T, error) {
+	items := make([]T, 0, SizeHintOf(iter).Min)
+	for item := range iter.Next {
+		items = append(items, item)
+	}
+
+	if iter.Err() != nil {
+		return items, iter.Err()
+	}
+
+	return items, nil
+}
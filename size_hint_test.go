@@ -0,0 +1,129 @@
+package goiterators_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dreadster3/goiterators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSizeHintPropagation(t *testing.T) {
+	source := goiterators.NewIteratorFromSlice([]int{1, 2, 3, 4, 5})
+	isEven := func(x int) bool { return x%2 == 0 }
+	double := func(x int) int { return x * 2 }
+
+	tests := []struct {
+		name     string
+		iterator goiterators.Iterator[int]
+		expected goiterators.SizeHint
+	}{
+		{"slice", source, goiterators.ExactSize(5)},
+		{"map", goiterators.Map(source, double), goiterators.ExactSize(5)},
+		{"map async", goiterators.MapAsync(source, double), goiterators.ExactSize(5)},
+		{"filter", goiterators.Filter(source, isEven), goiterators.SizeHint{Max: 5, Bounded: true}},
+		{"filter async", goiterators.FilterAsync(source, isEven), goiterators.SizeHint{Max: 5, Bounded: true}},
+		{"take", goiterators.Take(source, 3), goiterators.ExactSize(3)},
+		{"take more", goiterators.Take(source, 10), goiterators.ExactSize(5)},
+		{"take after filter", goiterators.Take(goiterators.Filter(source, isEven), 3), goiterators.SizeHint{Max: 3, Bounded: true}},
+		{"distinct", goiterators.Distinct(source), goiterators.SizeHint{Min: 1, Max: 5, Bounded: true}},
+		{"tap", goiterators.Tap(source, func(int) {}), goiterators.ExactSize(5)},
+		{"unknown", goiterators.NewIterator(func(yield func(int, int) bool) {}), goiterators.SizeHint{}},
+		{"take unknown", goiterators.Take(goiterators.NewIterator(func(yield func(int, int) bool) {}), 3), goiterators.SizeHint{Max: 3, Bounded: true}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, goiterators.SizeHintOf(test.iterator))
+		})
+	}
+}
+
+func TestSizeHintExact(t *testing.T) {
+	n, ok := goiterators.ExactSize(4).Exact()
+	assert.True(t, ok)
+	assert.Equal(t, 4, n)
+
+	_, ok = goiterators.SizeHint{Max: 4, Bounded: true}.Exact()
+	assert.False(t, ok)
+
+	_, ok = goiterators.SizeHint{}.Exact()
+	assert.False(t, ok)
+}
+
+func TestSizeHintOfForeignIterator(t *testing.T) {
+	assert.Equal(t, goiterators.SizeHint{}, goiterators.SizeHintOf[int](&countdown{from: 3}))
+}
+
+func TestCollect(t *testing.T) {
+	iterator := goiterators.NewIteratorFromSlice([]int{1, 2, 3})
+
+	result, err := goiterators.Collect(goiterators.Map(iterator, func(x int) int { return x * 2 }))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 4, 6}, result)
+	assert.Equal(t, 3, cap(result))
+}
+
+func TestCollectWithError(t *testing.T) {
+	next := func(yield func(int, error) bool) {
+		if !yield(1, nil) {
+			return
+		}
+		yield(0, errors.New("source error"))
+	}
+
+	result, err := goiterators.Collect(goiterators.NewIteratorErr(next))
+
+	assert.EqualError(t, err, "source error")
+	assert.Equal(t, []int{1}, result)
+}
+
+func TestMapAsyncCtxOrderedSmallInput(t *testing.T) {
+	iterator := goiterators.NewIteratorFromSlice([]int{3, 2, 1})
+
+	mapped := goiterators.MapAsyncCtx(context.Background(), iterator, func(ctx context.Context, x int) (int, error) {
+		return x * 10, nil
+	}, goiterators.WithOrdered(), goiterators.WithConcurrency(100))
+
+	result, err := goiterators.Collect(mapped)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{30, 20, 10}, result)
+}
+
+// underSized reports a bounded size hint smaller than the number of items it yields
+type underSized struct {
+	goiterators.Iterator[int]
+}
+
+func (underSized) SizeHint() goiterators.SizeHint {
+	return goiterators.ExactSize(1)
+}
+
+func TestMapAsyncCtxConcurrencyWithWrongSizeHint(t *testing.T) {
+	iterator := underSized{goiterators.NewIteratorFromSlice(make([]int, 10))}
+
+	var running, maxRunning atomic.Int32
+	mapped := goiterators.MapAsyncCtx(context.Background(), iterator, func(ctx context.Context, x int) (int, error) {
+		current := running.Add(1)
+		for {
+			peak := maxRunning.Load()
+			if current <= peak || maxRunning.CompareAndSwap(peak, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		running.Add(-1)
+		return x, nil
+	}, goiterators.WithConcurrency(2))
+
+	assert.Len(t, slices.Collect(mapped.Next), 10)
+	assert.NoError(t, mapped.Err())
+	// A hint below the concurrency limit must not lift it
+	assert.LessOrEqual(t, maxRunning.Load(), int32(2))
+}
@@ -0,0 +1,165 @@
+package goiterators
+
+// sliceSource gives random access to the items of a slice, offset is the index reported by INext for items[0]
+// A reversed source visits the items from the end of the slice
+type sliceSource[T any] struct {
+	items    []T
+	offset   int
+	reversed bool
+}
+
+func (s *sliceSource[T]) len() int {
+	return len(s.items)
+}
+
+// at returns the item at position i along with its index
+func (s *sliceSource[T]) at(i int) (int, T) {
+	if s.reversed {
+		i = len(s.items) - 1 - i
+	}
+
+	return s.offset + i, s.items[i]
+}
+
+// sub returns the items between positions from and to, keeping their indices
+func (s *sliceSource[T]) sub(from, to int) *sliceSource[T] {
+	if s.reversed {
+		from, to = len(s.items)-to, len(s.items)-from
+	}
+
+	return &sliceSource[T]{items: s.items[from:to], offset: s.offset + from, reversed: s.reversed}
+}
+
+// reverse returns the items in the opposite order, keeping their indices
+func (s *sliceSource[T]) reverse() *sliceSource[T] {
+	return &sliceSource[T]{items: s.items, offset: s.offset, reversed: !s.reversed}
+}
+
+// newSliceIterator creates an iterator over the items of source
+func newSliceIterator[T any](source *sliceSource[T]) *iterator[T] {
+	it := newIterator(func(self *iterator[T], yield func(int, T) bool) {
+		for i := range source.len() {
+			if !yield(source.at(i)) {
+				return
+			}
+		}
+	})
+	it.slice = source
+	it.size = ExactSize(source.len())
+
+	return it
+}
+
+// sliceOf returns the slice backing iter when it has random access to its items
+func sliceOf[T any](iter Iterator[T]) (*sliceSource[T], bool) {
+	if it, ok := iter.(*iterator[T]); ok && it.slice != nil {
+		return it.slice, true
+	}
+
+	return nil, false
+}
+
+// Skip drops the first n items of the iterator, the remaining items keep their index
+func Skip[T any](iter Iterator[T], n int) Iterator[T] {
+	n = max(n, 0)
+
+	if source, ok := sliceOf(iter); ok {
+		return withStage(newSliceIterator(source.sub(min(n, source.len()), source.len())), "Skip", nil, iter)
+	}
+
+	size := SizeHintOf(iter)
+	size.Min = max(size.Min-n, 0)
+	if size.Bounded {
+		size.Max = max(size.Max-n, 0)
+	}
+
+	return withSize(withStage(newIterator(func(self *iterator[T], yield func(int, T) bool) {
+		skipped := 0
+		for idx, item := range iter.INext {
+			if skipped < n {
+				skipped++
+				continue
+			}
+
+			if !yield(idx, item) {
+				return
+			}
+		}
+
+		if iter.Err() != nil {
+			self.err = iter.Err()
+		}
+	}), "Skip", nil, iter), size)
+}
+
+// TakeLast returns the last n items of the iterator, keeping their index
+// Items are buffered until the iterator ends unless it has random access to its items, and none are returned on error
+func TakeLast[T any](iter Iterator[T], n int) Iterator[T] {
+	n = max(n, 0)
+
+	if source, ok := sliceOf(iter); ok {
+		return withStage(newSliceIterator(source.sub(max(source.len()-n, 0), source.len())), "TakeLast", nil, iter)
+	}
+
+	return withSize(withStage(newIterator(func(self *iterator[T], yield func(int, T) bool) {
+		if n == 0 {
+			return
+		}
+
+		// Ring buffer of the last n items
+		indices := make([]int, 0, n)
+		items := make([]T, 0, n)
+		start := 0
+		for idx, item := range iter.INext {
+			if len(items) < n {
+				indices = append(indices, idx)
+				items = append(items, item)
+				continue
+			}
+
+			indices[start], items[start] = idx, item
+			start = (start + 1) % n
+		}
+
+		if iter.Err() != nil {
+			self.err = iter.Err()
+			return
+		}
+
+		for i := range items {
+			pos := (start + i) % len(items)
+			if !yield(indices[pos], items[pos]) {
+				return
+			}
+		}
+	}), "TakeLast", nil, iter), SizeHintOf(iter).limit(n))
+}
+
+// Reverse returns the items of the iterator from last to first, keeping their index
+// Items are buffered until the iterator ends unless it has random access to its items, and none are returned on error
+func Reverse[T any](iter Iterator[T]) Iterator[T] {
+	if source, ok := sliceOf(iter); ok {
+		return withStage(newSliceIterator(source.reverse()), "Reverse", nil, iter)
+	}
+
+	return withSize(withStage(newIterator(func(self *iterator[T], yield func(int, T) bool) {
+		capacity := SizeHintOf(iter).Min
+		indices := make([]int, 0, capacity)
+		items := make([]T, 0, capacity)
+		for idx, item := range iter.INext {
+			indices = append(indices, idx)
+			items = append(items, item)
+		}
+
+		if iter.Err() != nil {
+			self.err = iter.Err()
+			return
+		}
+
+		for i := len(items) - 1; i >= 0; i-- {
+			if !yield(indices[i], items[i]) {
+				return
+			}
+		}
+	}), "Reverse", nil, iter), SizeHintOf(iter))
+}
@@ -0,0 +1,259 @@
+package goiterators_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dreadster3/goiterators"
+	"github.com/stretchr/testify/assert"
+)
+
+// unindexed hides the random access of a slice iterator
+func unindexed[T any](data []T) goiterators.Iterator[T] {
+	return goiterators.NewIterator(slices.All(data))
+}
+
+func collectIndexed[T any](iterator goiterators.Iterator[T]) ([]int, []T) {
+	var indices []int
+	var items []T
+	for idx, item := range iterator.INext {
+		indices = append(indices, idx)
+		items = append(items, item)
+	}
+
+	return indices, items
+}
+
+func TestSkip(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5}
+	sources := map[string]goiterators.Iterator[int]{
+		"slice":     goiterators.NewIteratorFromSlice(data),
+		"unindexed": unindexed(data),
+	}
+
+	for name, source := range sources {
+		t.Run(name, func(t *testing.T) {
+			skipped := goiterators.Skip(source, 2)
+
+			indices, items := collectIndexed(skipped)
+			assert.Equal(t, []int{3, 4, 5}, items)
+			assert.Equal(t, []int{2, 3, 4}, indices)
+			assert.Empty(t, slices.Collect(goiterators.Skip(source, 10).Next))
+			assert.Equal(t, data, slices.Collect(goiterators.Skip(source, -1).Next))
+		})
+	}
+}
+
+func TestSkipSizeHint(t *testing.T) {
+	assert.Equal(t, goiterators.ExactSize(3), goiterators.SizeHintOf(goiterators.Skip(goiterators.NewIteratorFromSlice([]int{1, 2, 3, 4, 5}), 2)))
+
+	filtered := goiterators.Filter(goiterators.NewIteratorFromSlice([]int{1, 2, 3}), func(int) bool { return true })
+	assert.Equal(t, goiterators.SizeHint{Max: 1, Bounded: true}, goiterators.SizeHintOf(goiterators.Skip(filtered, 2)))
+}
+
+func TestTakeLast(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5}
+	sources := map[string]goiterators.Iterator[int]{
+		"slice":     goiterators.NewIteratorFromSlice(data),
+		"unindexed": unindexed(data),
+	}
+
+	for name, source := range sources {
+		t.Run(name, func(t *testing.T) {
+			indices, items := collectIndexed(goiterators.TakeLast(source, 2))
+			assert.Equal(t, []int{4, 5}, items)
+			assert.Equal(t, []int{3, 4}, indices)
+
+			assert.Equal(t, data, slices.Collect(goiterators.TakeLast(source, 10).Next))
+			assert.Empty(t, slices.Collect(goiterators.TakeLast(source, 0).Next))
+		})
+	}
+}
+
+func TestTakeLastWithError(t *testing.T) {
+	next := func(yield func(int, error) bool) {
+		if !yield(1, nil) {
+			return
+		}
+		yield(0, errors.New("source error"))
+	}
+
+	taken := goiterators.TakeLast(goiterators.NewIteratorErr(next), 1)
+
+	assert.Empty(t, slices.Collect(taken.Next))
+	assert.EqualError(t, taken.Err(), "source error")
+}
+
+func TestTakeSliceFastPath(t *testing.T) {
+	skipped := goiterators.Skip(goiterators.NewIteratorFromSlice([]int{1, 2, 3, 4, 5}), 1)
+	taken := goiterators.Take(skipped, 2)
+
+	indices, items := collectIndexed(taken)
+	assert.Equal(t, []int{2, 3}, items)
+	assert.Equal(t, []int{1, 2}, indices)
+	assert.Equal(t, goiterators.ExactSize(2), goiterators.SizeHintOf(taken))
+}
+
+func TestMapAsyncChunked(t *testing.T) {
+	data := make([]int, 1000)
+	for i := range data {
+		data[i] = i
+	}
+
+	var running, peak atomic.Int64
+	mapped := goiterators.IMapAsyncCtx(context.Background(), goiterators.NewIteratorFromSlice(data), func(ctx context.Context, idx int, x int) (int, error) {
+		current := running.Add(1)
+		defer running.Add(-1)
+		for {
+			seen := peak.Load()
+			if current <= seen || peak.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+
+		assert.Equal(t, idx, x)
+		return x * 2, nil
+	}, goiterators.WithConcurrency(4), goiterators.WithOrdered())
+
+	result, err := goiterators.Collect(mapped)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, len(data))
+	for i, value := range result {
+		assert.Equal(t, i*2, value)
+	}
+	assert.LessOrEqual(t, peak.Load(), int64(4))
+}
+
+func TestFilterAsyncChunkedUnordered(t *testing.T) {
+	data := make([]int, 100)
+	for i := range data {
+		data[i] = i
+	}
+
+	filtered := goiterators.FilterAsync(goiterators.NewIteratorFromSlice(data), func(x int) bool {
+		return x%10 == 0
+	}, goiterators.WithConcurrency(3))
+
+	result := slices.Collect(filtered.Next)
+	slices.Sort(result)
+
+	assert.Equal(t, []int{0, 10, 20, 30, 40, 50, 60, 70, 80, 90}, result)
+	assert.NoError(t, filtered.Err())
+}
+
+func TestMapAsyncCtxChunkedError(t *testing.T) {
+	data := make([]int, 100)
+	for i := range data {
+		data[i] = i
+	}
+
+	mapped := goiterators.MapAsyncCtx(context.Background(), goiterators.NewIteratorFromSlice(data), func(ctx context.Context, x int) (int, error) {
+		if x == 50 {
+			return 0, errors.New("item 50 failed")
+		}
+		return x, nil
+	}, goiterators.WithConcurrency(4), goiterators.WithOrdered())
+
+	result := slices.Collect(mapped.Next)
+
+	assert.EqualError(t, mapped.Err(), "item 50 failed")
+	assert.Equal(t, data[:50], result)
+}
+
+func TestForEachAsyncCtxChunkedCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	data := make([]int, 1000)
+
+	var processed atomic.Int64
+	err := goiterators.ForEachAsyncCtx(ctx, goiterators.NewIteratorFromSlice(data), func(ctx context.Context, x int) error {
+		if processed.Add(1) == 10 {
+			cancel()
+		}
+		return nil
+	}, goiterators.WithConcurrency(2))
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, processed.Load(), int64(len(data)))
+}
+
+func TestMapAsyncChunkedPanic(t *testing.T) {
+	mapped := goiterators.MapAsync(goiterators.NewIteratorFromSlice([]int{1, 2, 3}), func(x int) int {
+		if x == 2 {
+			panic("boom")
+		}
+		return x
+	}, goiterators.WithConcurrency(2))
+
+	_ = slices.Collect(mapped.Next)
+
+	var panicErr *goiterators.PanicError
+	assert.ErrorAs(t, mapped.Err(), &panicErr)
+}
+
+func benchmarkMapAsync(b *testing.B, source func([]int) goiterators.Iterator[int], opts ...goiterators.Option) {
+	data := make([]int, 10000)
+	for i := range data {
+		data[i] = i
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		mapped := goiterators.MapAsync(source(data), func(x int) int {
+			return x * x
+		}, opts...)
+
+		for range mapped.Next {
+		}
+	}
+}
+
+func BenchmarkMapAsyncSlice(b *testing.B) {
+	benchmarkMapAsync(b, goiterators.NewIteratorFromSlice[int], goiterators.WithConcurrency(8))
+}
+
+func BenchmarkMapAsyncSliceUnbounded(b *testing.B) {
+	benchmarkMapAsync(b, goiterators.NewIteratorFromSlice[int])
+}
+
+func BenchmarkMapAsyncUnindexed(b *testing.B) {
+	benchmarkMapAsync(b, unindexed[int], goiterators.WithConcurrency(8))
+}
+
+func BenchmarkTakeLastSlice(b *testing.B) {
+	data := make([]int, 100000)
+
+	for b.Loop() {
+		for range goiterators.TakeLast(goiterators.NewIteratorFromSlice(data), 10).Next {
+		}
+	}
+}
+
+func BenchmarkTakeLastUnindexed(b *testing.B) {
+	data := make([]int, 100000)
+
+	for b.Loop() {
+		for range goiterators.TakeLast(unindexed(data), 10).Next {
+		}
+	}
+}
+
+func TestTakeAfterSkipUnindexed(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6}
+	identity := func(x int) int { return x }
+	sources := map[string]goiterators.Iterator[int]{
+		"slice":     goiterators.NewIteratorFromSlice(data),
+		"unindexed": goiterators.Map(goiterators.NewIteratorFromSlice(data), identity),
+	}
+
+	for name, source := range sources {
+		t.Run(name, func(t *testing.T) {
+			indices, items := collectIndexed(goiterators.Take(goiterators.Skip(source, 3), 2))
+			assert.Equal(t, []int{4, 5}, items)
+			assert.Equal(t, []int{3, 4}, indices)
+		})
+	}
+}
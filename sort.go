@@ -0,0 +1,255 @@
+package goiterators
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"slices"
+)
+
+// lessToCmp adapts a less function to a three-way comparison
+func lessToCmp[T any](less func(a, b T) bool) func(a, b T) int {
+	return func(a, b T) int {
+		if less(a, b) {
+			return -1
+		}
+		if less(b, a) {
+			return 1
+		}
+		return 0
+	}
+}
+
+// Sorted collects every item and returns them in ascending order according to less, keeping equal items in their original order
+func Sorted[T any](iter Iterator[T], less func(a, b T) bool) Iterator[T] {
+	return withSize(withStage(newIterator(func(self *iterator[T], yield func(int, T) bool) {
+		items := make([]T, 0, SizeHintOf(iter).Min)
+		for item := range iter.Next {
+			items = append(items, item)
+		}
+
+		if iter.Err() != nil {
+			self.err = iter.Err()
+			return
+		}
+
+		slices.SortStableFunc(items, lessToCmp(less))
+		for idx, item := range items {
+			if !yield(idx, item) {
+				return
+			}
+		}
+	}), "Sorted", nil, iter), SizeHintOf(iter))
+}
+
+// maxMergeFanIn bounds the number of runs merged at once, and so the number of spill files open at the same time
+const maxMergeFanIn = 64
+
+// SortedExternal sorts items that may not fit in memory by sorting runs of at most memLimit items,
+// spilling them to temporary files in tmpDir using codec and lazily merging the runs back together
+// memLimit is a number of items, not bytes, so it must account for the size of the items
+// Runs are merged in passes of at most 64 files so that large inputs do not exhaust file descriptors
+// An empty tmpDir uses the default directory for temporary files, the files are removed once the iteration ends
+func SortedExternal[T any](iter Iterator[T], less func(a, b T) bool, codec Codec[T], memLimit int, tmpDir string) Iterator[T] {
+	memLimit = max(memLimit, 1)
+
+	return withSize(withStage(newIterator(func(self *iterator[T], yield func(int, T) bool) {
+		var runs, spilled []*spillFile[T]
+		defer func() {
+			for _, run := range spilled {
+				run.remove()
+			}
+		}()
+
+		buffer := make([]T, 0, memLimit)
+		for item := range iter.Next {
+			buffer = append(buffer, item)
+			if len(buffer) < memLimit {
+				continue
+			}
+
+			slices.SortStableFunc(buffer, lessToCmp(less))
+			run, err := spill(buffer, codec, tmpDir)
+			if err != nil {
+				self.err = err
+				return
+			}
+
+			runs = append(runs, run)
+			spilled = append(spilled, run)
+			buffer = buffer[:0]
+		}
+
+		if iter.Err() != nil {
+			self.err = iter.Err()
+			return
+		}
+
+		for len(runs) > maxMergeFanIn {
+			merged, err := mergeRuns(runs, less, codec, tmpDir)
+			spilled = append(spilled, merged...)
+			if err != nil {
+				self.err = err
+				return
+			}
+			runs = merged
+		}
+
+		slices.SortStableFunc(buffer, lessToCmp(less))
+
+		sources := make([]pullFunc[T], 0, len(runs)+1)
+		for _, run := range runs {
+			pull, err := run.reader()
+			if err != nil {
+				self.err = err
+				return
+			}
+			sources = append(sources, pull)
+		}
+		// The last run stays in memory and comes last to keep the sort stable
+		sources = append(sources, pullSlice(buffer))
+
+		idx := 0
+		err := kWayMerge(less, sources, func(item T) bool {
+			if !yield(idx, item) {
+				return false
+			}
+			idx++
+			return true
+		})
+		if err != nil {
+			self.err = err
+		}
+	}), "SortedExternal", nil, iter), SizeHintOf(iter))
+}
+
+// pullSlice returns a pullFunc over the items of a slice
+func pullSlice[T any](items []T) pullFunc[T] {
+	pos := 0
+	return func() (T, bool, error) {
+		if pos >= len(items) {
+			return *new(T), false, nil
+		}
+
+		item := items[pos]
+		pos++
+		return item, true, nil
+	}
+}
+
+// mergeRuns merges every group of at most maxMergeFanIn consecutive runs into a new run and removes the merged runs
+// Consecutive runs are merged together to keep the sort stable, the new runs are returned even on error so they can be removed
+func mergeRuns[T any](runs []*spillFile[T], less func(a, b T) bool, codec Codec[T], dir string) ([]*spillFile[T], error) {
+	var merged []*spillFile[T]
+	for group := range slices.Chunk(runs, maxMergeFanIn) {
+		sources := make([]pullFunc[T], 0, len(group))
+		for _, run := range group {
+			pull, err := run.reader()
+			if err != nil {
+				return merged, err
+			}
+			sources = append(sources, pull)
+		}
+
+		run, err := createRun(codec, dir, func(encoder Encoder[T]) error {
+			var encodeErr error
+			err := kWayMerge(less, sources, func(item T) bool {
+				encodeErr = encoder.Encode(item)
+				return encodeErr == nil
+			})
+			if err != nil {
+				return err
+			}
+			return encodeErr
+		})
+		if err != nil {
+			return merged, err
+		}
+
+		merged = append(merged, run)
+		for _, run := range group {
+			run.remove()
+		}
+	}
+
+	return merged, nil
+}
+
+// spillFile is a temporary file holding encoded items, only open while it is read
+type spillFile[T any] struct {
+	path  string
+	file  *os.File
+	codec Codec[T]
+}
+
+// spill writes items to a new temporary file in dir
+func spill[T any](items []T, codec Codec[T], dir string) (*spillFile[T], error) {
+	return createRun(codec, dir, func(encoder Encoder[T]) error {
+		for _, item := range items {
+			if err := encoder.Encode(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// createRun writes the items encoded by write to a new temporary file in dir and closes it
+func createRun[T any](codec Codec[T], dir string, write func(Encoder[T]) error) (*spillFile[T], error) {
+	file, err := os.CreateTemp(dir, "goiterators-*")
+	if err != nil {
+		return nil, err
+	}
+
+	run := &spillFile[T]{path: file.Name(), file: file, codec: codec}
+	writer := bufio.NewWriter(file)
+	if err := write(codec.NewEncoder(writer)); err != nil {
+		run.remove()
+		return nil, err
+	}
+
+	if err := writer.Flush(); err != nil {
+		run.remove()
+		return nil, err
+	}
+
+	run.file = nil
+	if err := file.Close(); err != nil {
+		run.remove()
+		return nil, err
+	}
+
+	return run, nil
+}
+
+// reader opens the file and returns a pullFunc decoding its items, the file stays open until the run is removed
+func (s *spillFile[T]) reader() (pullFunc[T], error) {
+	file, err := os.Open(s.path)
+	if err != nil {
+		return nil, err
+	}
+	s.file = file
+
+	decoder := s.codec.NewDecoder(bufio.NewReader(file))
+	return func() (T, bool, error) {
+		item, err := decoder.Decode()
+		if errors.Is(err, io.EOF) {
+			return *new(T), false, nil
+		}
+		if err != nil {
+			return *new(T), false, err
+		}
+
+		return item, true, nil
+	}, nil
+}
+
+// remove closes the file when it is open and deletes it, removing a run twice is a no-op
+func (s *spillFile[T]) remove() {
+	if s.file != nil {
+		_ = s.file.Close()
+		s.file = nil
+	}
+	_ = os.Remove(s.path)
+}
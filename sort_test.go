@@ -0,0 +1,178 @@
+package goiterators_test
+
+import (
+	"errors"
+	"math/rand/v2"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dreadster3/goiterators"
+	"github.com/stretchr/testify/assert"
+)
+
+type record struct {
+	Key   int
+	Value string
+}
+
+func TestSorted(t *testing.T) {
+	data := []int{5, 3, 1, 4, 2}
+	iterator := goiterators.NewIteratorFromSlice(data)
+
+	sorted := goiterators.Sorted(iterator, func(a, b int) bool { return a < b })
+
+	var indices []int
+	var result []int
+	for idx, item := range sorted.INext {
+		indices = append(indices, idx)
+		result = append(result, item)
+	}
+
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, result)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, indices)
+	assert.NoError(t, sorted.Err())
+}
+
+func TestSortedStable(t *testing.T) {
+	data := []record{{2, "a"}, {1, "b"}, {2, "c"}, {1, "d"}}
+	iterator := goiterators.NewIteratorFromSlice(data)
+
+	sorted := goiterators.Sorted(iterator, func(a, b record) bool { return a.Key < b.Key })
+	result := slices.Collect(sorted.Next)
+
+	assert.Equal(t, []record{{1, "b"}, {1, "d"}, {2, "a"}, {2, "c"}}, result)
+}
+
+func TestSortedWithError(t *testing.T) {
+	next := func(yield func(int, error) bool) {
+		if !yield(2, nil) {
+			return
+		}
+		yield(0, errors.New("source error"))
+	}
+
+	sorted := goiterators.Sorted(goiterators.NewIteratorErr(next), func(a, b int) bool { return a < b })
+	result := slices.Collect(sorted.Next)
+
+	assert.Empty(t, result)
+	assert.EqualError(t, sorted.Err(), "source error")
+}
+
+func TestSortedExternal(t *testing.T) {
+	codecs := map[string]goiterators.Codec[record]{
+		"gob":  goiterators.GobCodec[record](),
+		"json": goiterators.JSONCodec[record](),
+	}
+
+	for name, codec := range codecs {
+		t.Run(name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+
+			data := make([]record, 1000)
+			for i := range data {
+				data[i] = record{Key: rand.IntN(100), Value: string(rune('a' + i%26))}
+			}
+
+			less := func(a, b record) bool { return a.Key < b.Key }
+			sorted := goiterators.SortedExternal(goiterators.NewIteratorFromSlice(data), less, codec, 64, tmpDir)
+			result := slices.Collect(sorted.Next)
+
+			expected := slices.Clone(data)
+			slices.SortStableFunc(expected, func(a, b record) int { return a.Key - b.Key })
+
+			assert.Equal(t, expected, result)
+			assert.NoError(t, sorted.Err())
+
+			entries, err := os.ReadDir(tmpDir)
+			assert.NoError(t, err)
+			assert.Empty(t, entries, "Expected spill files to be removed")
+		})
+	}
+}
+
+func TestSortedExternalEarlyStop(t *testing.T) {
+	tmpDir := t.TempDir()
+	data := []int{9, 8, 7, 6, 5, 4, 3, 2, 1}
+
+	sorted := goiterators.SortedExternal(goiterators.NewIteratorFromSlice(data), func(a, b int) bool { return a < b }, goiterators.GobCodec[int](), 2, tmpDir)
+	result := slices.Collect(goiterators.Take(sorted, 3).Next)
+
+	assert.Equal(t, []int{1, 2, 3}, result)
+
+	entries, err := os.ReadDir(tmpDir)
+	assert.NoError(t, err)
+	assert.Empty(t, entries, "Expected spill files to be removed")
+}
+
+func TestSortedExternalInMemory(t *testing.T) {
+	tmpDir := t.TempDir()
+	data := []int{3, 1, 2}
+
+	sorted := goiterators.SortedExternal(goiterators.NewIteratorFromSlice(data), func(a, b int) bool { return a < b }, goiterators.GobCodec[int](), 10, tmpDir)
+
+	assert.Equal(t, []int{1, 2, 3}, slices.Collect(sorted.Next))
+	assert.NoError(t, sorted.Err())
+}
+
+func TestSortedExternalInvalidDir(t *testing.T) {
+	data := []int{3, 1, 2}
+
+	sorted := goiterators.SortedExternal(goiterators.NewIteratorFromSlice(data), func(a, b int) bool { return a < b }, goiterators.GobCodec[int](), 1, "/nonexistent/goiterators")
+	result := slices.Collect(sorted.Next)
+
+	assert.Empty(t, result)
+	assert.Error(t, sorted.Err())
+}
+
+// openFilesIn counts the files of dir opened by the process, it reports false when open files cannot be listed
+func openFilesIn(dir string) (int, bool) {
+	fds, err := os.ReadDir("/proc/self/fd")
+	if err != nil {
+		return 0, false
+	}
+
+	count := 0
+	for _, fd := range fds {
+		target, err := os.Readlink("/proc/self/fd/" + fd.Name())
+		if err == nil && strings.HasPrefix(target, dir) {
+			count++
+		}
+	}
+
+	return count, true
+}
+
+func TestSortedExternalManyRuns(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// A run per item needs several merge passes
+	data := make([]record, 300)
+	for i := range data {
+		data[i] = record{Key: rand.IntN(10), Value: string(rune('a' + i%26))}
+	}
+
+	less := func(a, b record) bool { return a.Key < b.Key }
+	sorted := goiterators.SortedExternal(goiterators.NewIteratorFromSlice(data), less, goiterators.GobCodec[record](), 1, tmpDir)
+
+	var result []record
+	for item := range sorted.Next {
+		if len(result) == 0 {
+			if open, ok := openFilesIn(tmpDir); ok {
+				assert.LessOrEqual(t, open, 64, "Expected the merge to bound the number of open spill files")
+			}
+		}
+		result = append(result, item)
+	}
+
+	expected := slices.Clone(data)
+	slices.SortStableFunc(expected, func(a, b record) int { return a.Key - b.Key })
+
+	assert.Equal(t, expected, result)
+	assert.NoError(t, sorted.Err())
+
+	entries, err := os.ReadDir(tmpDir)
+	assert.NoError(t, err)
+	assert.Empty(t, entries, "Expected spill files to be removed")
+}
@@ -0,0 +1,56 @@
+package goiterators
+
+import (
+	"fmt"
+	"io"
+)
+
+// Tap calls fn for every item without altering the stream
+func Tap[T any](iterator Iterator[T], fn func(T)) Iterator[T] {
+	return ITap(iterator, func(_ int, item T) {
+		fn(item)
+	})
+}
+
+// ITap calls fn for every item with index without altering the stream
+func ITap[T any](iter Iterator[T], fn func(int, T)) Iterator[T] {
+	return withSize(withStage(newIterator(func(self *iterator[T], yield func(int, T) bool) {
+		for idx, item := range iter.INext {
+			fn(idx, item)
+			if !yield(idx, item) {
+				return
+			}
+		}
+
+		if iter.Err() != nil {
+			self.err = iter.Err()
+		}
+	}), "Tap", nil, iter), SizeHintOf(iter))
+}
+
+// TapErr calls fn with the error the iterator stopped with, it is not called when the iteration succeeds or is stopped early
+func TapErr[T any](iter Iterator[T], fn func(error)) Iterator[T] {
+	return withSize(withStage(newIterator(func(self *iterator[T], yield func(int, T) bool) {
+		for idx, item := range iter.INext {
+			if !yield(idx, item) {
+				return
+			}
+		}
+
+		if iter.Err() != nil {
+			fn(iter.Err())
+			self.err = iter.Err()
+		}
+	}), "TapErr", nil, iter), SizeHintOf(iter))
+}
+
+// Debug writes every item with its index and the terminal error to w, each line starting with prefix
+func Debug[T any](iter Iterator[T], w io.Writer, prefix string) Iterator[T] {
+	tapped := ITap(iter, func(idx int, item T) {
+		fmt.Fprintf(w, "%s[%d]: %+v\n", prefix, idx, item)
+	})
+
+	return TapErr(tapped, func(err error) {
+		fmt.Fprintf(w, "%s error: %v\n", prefix, err)
+	})
+}
@@ -0,0 +1,92 @@
+package goiterators_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dreadster3/goiterators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTap(t *testing.T) {
+	iterator := goiterators.NewIteratorFromSlice([]int{1, 2, 3})
+
+	var seen []int
+	tapped := goiterators.Tap(iterator, func(x int) {
+		seen = append(seen, x)
+	})
+
+	assert.Equal(t, []int{1, 2, 3}, slices.Collect(tapped.Next))
+	assert.Equal(t, []int{1, 2, 3}, seen)
+	assert.NoError(t, tapped.Err())
+}
+
+func TestITapBetweenOperations(t *testing.T) {
+	iterator := goiterators.NewIteratorFromSlice([]int{1, 2, 3, 4})
+
+	var indices []int
+	filtered := goiterators.Filter(iterator, func(x int) bool { return x%2 == 0 })
+	tapped := goiterators.ITap(filtered, func(idx int, x int) {
+		indices = append(indices, idx)
+	})
+	mapped := goiterators.Map(tapped, func(x int) int { return x * 10 })
+
+	assert.Equal(t, []int{20, 40}, slices.Collect(mapped.Next))
+	assert.Equal(t, []int{1, 3}, indices)
+}
+
+func TestTapEarlyStop(t *testing.T) {
+	iterator := goiterators.NewIteratorFromSlice([]int{1, 2, 3})
+
+	calls := 0
+	tapped := goiterators.Tap(iterator, func(int) { calls++ })
+
+	assert.Equal(t, []int{1}, slices.Collect(goiterators.Take(tapped, 1).Next))
+	assert.Equal(t, 1, calls)
+}
+
+func TestTapErr(t *testing.T) {
+	next := func(yield func(int, error) bool) {
+		if !yield(1, nil) {
+			return
+		}
+		yield(0, errors.New("source error"))
+	}
+
+	var observed error
+	tapped := goiterators.TapErr(goiterators.NewIteratorErr(next), func(err error) {
+		observed = err
+	})
+
+	assert.Equal(t, []int{1}, slices.Collect(tapped.Next))
+	assert.EqualError(t, observed, "source error")
+	assert.EqualError(t, tapped.Err(), "source error")
+}
+
+func TestTapErrNotCalledOnSuccess(t *testing.T) {
+	iterator := goiterators.NewIteratorFromSlice([]int{1, 2})
+
+	called := false
+	tapped := goiterators.TapErr(iterator, func(error) { called = true })
+
+	assert.Equal(t, []int{1, 2}, slices.Collect(tapped.Next))
+	assert.False(t, called)
+}
+
+func TestDebug(t *testing.T) {
+	next := func(yield func(string, error) bool) {
+		if !yield("a", nil) || !yield("b", nil) {
+			return
+		}
+		yield("", errors.New("source error"))
+	}
+
+	var builder strings.Builder
+	debugged := goiterators.Debug(goiterators.NewIteratorErr(next), &builder, "source")
+
+	assert.Equal(t, []string{"a", "b"}, slices.Collect(debugged.Next))
+	assert.Equal(t, "source[0]: a\nsource[1]: b\nsource error: source error\n", builder.String())
+	assert.EqualError(t, debugged.Err(), "source error")
+}
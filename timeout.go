@@ -0,0 +1,57 @@
+package goiterators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ItemTimeoutError is returned when an item function does not complete within the item timeout
+type ItemTimeoutError struct {
+	Index   int
+	Timeout time.Duration
+}
+
+func (e *ItemTimeoutError) Error() string {
+	return fmt.Sprintf("item %d timed out after %v", e.Index, e.Timeout)
+}
+
+func (e *ItemTimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// WithItemTimeout bounds every item, including its retries, to the given duration
+// The item function receives a context with the deadline, and an item that overruns it fails with an ItemTimeoutError
+// even if the function ignores its context, so a hung item never stalls the iterator
+func WithItemTimeout(timeout time.Duration) Option {
+	return func(c *config) {
+		c.itemTimeout = timeout
+	}
+}
+
+// withItemTimeout returns a callFunc that abandons fn for the item at idx once the timeout elapses
+func withItemTimeout(idx int, timeout time.Duration, fn callFunc) callFunc {
+	return func(ctx context.Context) error {
+		itemCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		done := make(chan error, 1)
+		go func() {
+			done <- fn(itemCtx)
+		}()
+
+		select {
+		case err := <-done:
+			if err != nil && ctx.Err() == nil && errors.Is(itemCtx.Err(), context.DeadlineExceeded) {
+				return &ItemTimeoutError{Index: idx, Timeout: timeout}
+			}
+			return err
+		case <-itemCtx.Done():
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return &ItemTimeoutError{Index: idx, Timeout: timeout}
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package goiterators_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dreadster3/goiterators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIMapAsyncCtxWithItemTimeout(t *testing.T) {
+	data := []int{1, 2, 3}
+	iterator := goiterators.NewIteratorFromSlice(data)
+
+	mapped := goiterators.IMapAsyncCtx(context.Background(), iterator, func(ctx context.Context, idx int, x int) (int, error) {
+		if idx == 1 {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}
+		return x * 2, nil
+	}, goiterators.WithItemTimeout(20*time.Millisecond))
+
+	_ = slices.Collect(mapped.Next)
+
+	var timeoutErr *goiterators.ItemTimeoutError
+	assert.ErrorAs(t, mapped.Err(), &timeoutErr)
+	assert.Equal(t, 1, timeoutErr.Index)
+	assert.Equal(t, 20*time.Millisecond, timeoutErr.Timeout)
+	assert.ErrorIs(t, mapped.Err(), context.DeadlineExceeded)
+}
+
+func TestMapAsyncCtxWithItemTimeoutHungItem(t *testing.T) {
+	iterator := goiterators.NewIteratorFromSlice([]int{1})
+	release := make(chan struct{})
+	defer close(release)
+
+	start := time.Now()
+	mapped := goiterators.MapAsyncCtx(context.Background(), iterator, func(ctx context.Context, x int) (int, error) {
+		<-release // Ignores its context entirely
+		return x, nil
+	}, goiterators.WithItemTimeout(20*time.Millisecond))
+
+	result := slices.Collect(mapped.Next)
+	elapsed := time.Since(start)
+
+	assert.Empty(t, result)
+	var timeoutErr *goiterators.ItemTimeoutError
+	assert.ErrorAs(t, mapped.Err(), &timeoutErr)
+	assert.Less(t, elapsed, 500*time.Millisecond, "Expected hung item to be abandoned")
+}
+
+func TestMapAsyncCtxWithItemTimeoutNotExceeded(t *testing.T) {
+	data := []int{1, 2, 3}
+	iterator := goiterators.NewIteratorFromSlice(data)
+
+	mapped := goiterators.MapAsyncCtx(context.Background(), iterator, func(ctx context.Context, x int) (int, error) {
+		_, hasDeadline := ctx.Deadline()
+		assert.True(t, hasDeadline)
+		return x * 2, nil
+	}, goiterators.WithItemTimeout(time.Second))
+
+	result := slices.Collect(mapped.Next)
+	slices.Sort(result)
+
+	assert.Equal(t, []int{2, 4, 6}, result)
+	assert.NoError(t, mapped.Err())
+}
+
+func TestForEachAsyncCtxWithItemTimeoutAndRetry(t *testing.T) {
+	iterator := goiterators.NewIteratorFromSlice([]int{1})
+
+	var calls atomic.Int32
+	err := goiterators.ForEachAsyncCtx(context.Background(), iterator, func(ctx context.Context, x int) error {
+		calls.Add(1)
+		return errors.New("transient")
+	}, goiterators.WithItemTimeout(50*time.Millisecond), goiterators.WithRetry(goiterators.RetryPolicy{
+		MaxAttempts:    100,
+		InitialBackoff: 10 * time.Millisecond,
+		Multiplier:     1,
+	}))
+
+	// Retries happen within the item deadline
+	var timeoutErr *goiterators.ItemTimeoutError
+	assert.ErrorAs(t, err, &timeoutErr)
+	assert.Greater(t, calls.Load(), int32(1))
+	assert.Less(t, calls.Load(), int32(100))
+}
+
+func TestMapAsyncCtxWithItemTimeoutParentCancellation(t *testing.T) {
+	iterator := goiterators.NewIteratorFromSlice([]int{1})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	mapped := goiterators.MapAsyncCtx(ctx, iterator, func(ctx context.Context, x int) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}, goiterators.WithItemTimeout(time.Second))
+
+	_ = slices.Collect(mapped.Next)
+
+	assert.ErrorIs(t, mapped.Err(), context.Canceled)
+	var timeoutErr *goiterators.ItemTimeoutError
+	assert.False(t, errors.As(mapped.Err(), &timeoutErr))
+}